@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"github.com/andygello555/gotils/v2/slices"
 	"github.com/machinebox/graphql"
+	"github.com/pkg/errors"
 	"net/http"
 	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -29,6 +33,71 @@ func (req GraphQLRequest) Header() *http.Header {
 	return &req.Request.Header
 }
 
+// RecordableGraphQLRequest is a GraphQLRequest that also keeps the query document and variables that it was created
+// with, as graphql.Request does not expose them. This allows the RecordingClient, ReplayClient, DumpRequest, and the
+// errors returned by Binding.Execute to describe the request. RecordableGraphQLRequest(s) should be created using
+// NewGraphQLRequest, and a Client can run the embedded GraphQLRequest.
+type RecordableGraphQLRequest struct {
+	GraphQLRequest
+	query string
+	vars  map[string]any
+}
+
+// NewGraphQLRequest creates a RecordableGraphQLRequest for the given query document and variables.
+func NewGraphQLRequest(query string, vars map[string]any) RecordableGraphQLRequest {
+	req := RecordableGraphQLRequest{
+		GraphQLRequest: GraphQLRequest{graphql.NewRequest(query)},
+		query:          query,
+		vars:           make(map[string]any, len(vars)),
+	}
+	for name, value := range vars {
+		req.Var(name, value)
+	}
+	return req
+}
+
+// Var sets a variable on both the RecordableGraphQLRequest and the underlying graphql.Request.
+func (req RecordableGraphQLRequest) Var(key string, value any) {
+	req.Request.Var(key, value)
+	req.vars[key] = value
+}
+
+// Query returns the query document of the RecordableGraphQLRequest.
+func (req RecordableGraphQLRequest) Query() string { return req.query }
+
+// Vars returns the variables of the RecordableGraphQLRequest. The returned map should not be modified, use Var
+// instead.
+func (req RecordableGraphQLRequest) Vars() map[string]any { return req.vars }
+
+// graphQLOperationPattern matches the operation type and name at the start of a GraphQL query document.
+var graphQLOperationPattern = regexp.MustCompile(`^\s*(query|mutation|subscription)\s+([_A-Za-z][_0-9A-Za-z]*)`)
+
+// OperationName returns the name of the GraphQL operation within the query of the RecordableGraphQLRequest. The second
+// return value is false if the operation is anonymous.
+func (req RecordableGraphQLRequest) OperationName() (string, bool) {
+	if match := graphQLOperationPattern.FindStringSubmatch(req.query); match != nil {
+		return match[2], true
+	}
+	return "", false
+}
+
+// describeRequest returns a short description of the given Request to use within errors. For a HTTPRequest this is
+// the method and URL of the request, and for a RecordableGraphQLRequest this is the name of the operation. An empty
+// string is returned if the Request cannot be described.
+func describeRequest(req Request) string {
+	switch req := req.(type) {
+	case HTTPRequest:
+		if req.Request != nil && req.URL != nil {
+			return fmt.Sprintf("%s %s", req.Method, req.URL.String())
+		}
+	case RecordableGraphQLRequest:
+		if name, ok := req.OperationName(); ok {
+			return fmt.Sprintf("GraphQL operation %q", name)
+		}
+	}
+	return ""
+}
+
 // Request is the request instance that is constructed by the Binding.Request method.
 type Request interface {
 	// Header returns a reference to the http.Header for the underlying http.Request that can be modified in any way
@@ -85,6 +154,41 @@ type RateLimitedClient interface {
 	Log(string)
 }
 
+// HeaderRecorder is an optional interface that can be implemented by a Client to surface the http.Header of the
+// responses that it receives. This is required by Paginator(s) that paginate using Link headers (see
+// LinkHeaderPagination).
+type HeaderRecorder interface {
+	// LatestHeader returns the http.Header of the latest response received by Client.Run for the Binding of the given
+	// name. It should return nil if there is no response for that Binding.
+	LatestHeader(bindingName string) http.Header
+}
+
+// ResponseMeta holds the metadata of a response received by a Client.
+type ResponseMeta struct {
+	StatusCode int
+	Header     http.Header
+}
+
+// MetaRecorder is an optional interface that can be implemented by a Client to surface the ResponseMeta of the
+// responses that it receives. This is used by Binding.ExecuteWithResponse.
+type MetaRecorder interface {
+	// LatestMeta returns the ResponseMeta of the latest response received by Client.Run for the Binding of the given
+	// name. The second return value should be false if there is no response for that Binding.
+	LatestMeta(bindingName string) (ResponseMeta, bool)
+}
+
+// latestResponseMeta returns the ResponseMeta of the latest response for the Binding of the given name, using the
+// MetaRecorder or HeaderRecorder implementation of the given Client.
+func latestResponseMeta(client Client, bindingName string) (meta ResponseMeta) {
+	switch recorder := client.(type) {
+	case MetaRecorder:
+		meta, _ = recorder.LatestMeta(bindingName)
+	case HeaderRecorder:
+		meta.Header = recorder.LatestHeader(bindingName)
+	}
+	return
+}
+
 // BindingWrapper wraps a Binding value with its name. This is used within the Schema map so that we don't have to use
 // type parameters everywhere.
 type BindingWrapper struct {
@@ -110,11 +214,70 @@ func (bw BindingWrapper) Paginated() bool {
 	return bw.binding.MethodByName("Paginated").Call([]reflect.Value{})[0].Bool()
 }
 
+// PaginatorParamSet calls the Binding.PaginatorParamSet method for the underlying Binding in the BindingWrapper.
+func (bw BindingWrapper) PaginatorParamSet() PaginatorParamConfig {
+	return bw.binding.MethodByName("PaginatorParamSet").Call([]reflect.Value{})[0].Interface().(PaginatorParamConfig)
+}
+
+// Client calls the Binding.Client method for the underlying Binding in the BindingWrapper.
+func (bw BindingWrapper) Client() Client {
+	client, _ := bw.binding.MethodByName("Client").Call([]reflect.Value{})[0].Interface().(Client)
+	return client
+}
+
+// Value returns the reflect.Value of the underlying Binding in the BindingWrapper. The reflect.Value holds the
+// Binding[ResT, RetT] interface, so the methods of the Binding can be called using reflect.Value.MethodByName.
+func (bw BindingWrapper) Value() reflect.Value { return bw.binding }
+
+// With calls the setter method of the given name on the underlying Binding in the BindingWrapper with the given
+// arguments, and returns a copy of the BindingWrapper that wraps the Binding returned by the setter. This allows
+// setters, such as Binding.SetRetryPolicy and Binding.SetClient, to be used without knowing the type parameters of the
+// Binding. An error is returned if the method does not exist, or if it does not return a Binding of the same type.
+func (bw BindingWrapper) With(method string, args ...any) (wrapper BindingWrapper, err error) {
+	setter := bw.binding.MethodByName(method)
+	if !setter.IsValid() {
+		return bw, fmt.Errorf("Binding %s has no method %q", bw.binding.Type(), method)
+	}
+
+	if setter.Type().NumOut() != 1 || setter.Type().Out(0) != bw.binding.Type() {
+		return bw, fmt.Errorf("method %q of Binding %s does not return a %s", method, bw.binding.Type(), bw.binding.Type())
+	}
+
+	arguments := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		if i < setter.Type().NumIn() && arg == nil {
+			arguments[i] = reflect.Zero(setter.Type().In(i))
+			continue
+		}
+		arguments[i] = reflect.ValueOf(arg)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("could not call method %q of Binding %s: %v", method, bw.binding.Type(), p)
+		}
+	}()
+	bw.binding = setter.Call(arguments)[0]
+	return bw, nil
+}
+
 // Paginator returns an un-typed Paginator for the underlying Binding of the BindingWrapper.
 func (bw BindingWrapper) Paginator(client Client, waitTime time.Duration, args ...any) (paginator Paginator[any, any], err error) {
 	return NewPaginator(client, waitTime, bw, args...)
 }
 
+// PaginatorCtx returns an un-typed Paginator for the underlying Binding of the BindingWrapper that will execute the
+// Binding using the given context.Context.
+func (bw BindingWrapper) PaginatorCtx(ctx context.Context, client Client, waitTime time.Duration, args ...any) (paginator Paginator[any, any], err error) {
+	return NewPaginatorCtx(ctx, client, waitTime, bw, args...)
+}
+
+// RequestTemplate calls the Binding.RequestTemplate method for the underlying Binding in the BindingWrapper.
+func (bw BindingWrapper) RequestTemplate() (method string, urlTemplate string, ok bool) {
+	values := bw.binding.MethodByName("RequestTemplate").Call([]reflect.Value{})
+	return values[0].String(), values[1].String(), values[2].Bool()
+}
+
 // ArgsFromStrings calls the Binding.ArgsFromStrings method for the underlying Binding in the BindingWrapper.
 func (bw BindingWrapper) ArgsFromStrings(args ...string) (parsedArgs []any, err error) {
 	values := bw.binding.MethodByName("ArgsFromStrings").Call(slices.Comprehension(args, func(idx int, value string, arr []string) reflect.Value {
@@ -133,13 +296,24 @@ func (bw BindingWrapper) Params() []BindingParam {
 	return bw.binding.MethodByName("Params").Call([]reflect.Value{})[0].Interface().([]BindingParam)
 }
 
+// interfaceValue returns the reflect.Value for the given value as an interface. Unlike reflect.ValueOf, this will return
+// a valid reflect.Value for a nil interface, so that it can be passed to reflect.Value.Call.
+func interfaceValue[T any](value T) reflect.Value {
+	return reflect.ValueOf(&value).Elem()
+}
+
 // Execute calls the Binding.Execute method for the underlying Binding in the BindingWrapper.
 func (bw BindingWrapper) Execute(client Client, args ...any) (val any, err error) {
-	arguments := []any{client}
-	arguments = append(arguments, args...)
-	values := bw.binding.MethodByName("Execute").Call(slices.Comprehension(arguments, func(idx int, value any, arr []any) reflect.Value {
-		return reflect.ValueOf(value)
-	}))
+	return bw.ExecuteCtx(context.Background(), client, args...)
+}
+
+// ExecuteCtx calls the Binding.ExecuteCtx method for the underlying Binding in the BindingWrapper.
+func (bw BindingWrapper) ExecuteCtx(ctx context.Context, client Client, args ...any) (val any, err error) {
+	arguments := []reflect.Value{interfaceValue(ctx), interfaceValue(client)}
+	arguments = append(arguments, slices.Comprehension(args, func(idx int, value any, arr []any) reflect.Value {
+		return interfaceValue(value)
+	})...)
+	values := bw.binding.MethodByName("ExecuteCtx").Call(arguments)
 	val = values[0].Interface()
 	err = nil
 	if !values[1].IsNil() {
@@ -148,22 +322,112 @@ func (bw BindingWrapper) Execute(client Client, args ...any) (val any, err error
 	return
 }
 
-func (bw BindingWrapper) setName(name string) {
-	fmt.Println("setName", bw.binding.Type())
-	bw.binding.MethodByName("SetName").Call([]reflect.Value{reflect.ValueOf(name)})
+// ExecuteRaw calls the Binding.ExecuteRaw method for the underlying Binding in the BindingWrapper.
+func (bw BindingWrapper) ExecuteRaw(ctx context.Context, client Client, args ...any) (val any, responseWrapper reflect.Value, err error) {
+	arguments := []reflect.Value{interfaceValue(ctx), interfaceValue(client)}
+	arguments = append(arguments, slices.Comprehension(args, func(idx int, value any, arr []any) reflect.Value {
+		return interfaceValue(value)
+	})...)
+	values := bw.binding.MethodByName("ExecuteRaw").Call(arguments)
+	val = values[0].Interface()
+	responseWrapper = values[1].Interface().(reflect.Value)
+	err = nil
+	if !values[2].IsNil() {
+		err = values[2].Interface().(error)
+	}
+	return
+}
+
+// ExecuteWithResponse calls the Binding.ExecuteWithResponse method for the underlying Binding in the BindingWrapper.
+func (bw BindingWrapper) ExecuteWithResponse(client Client, args ...any) (val any, meta ResponseMeta, err error) {
+	arguments := []reflect.Value{interfaceValue(client)}
+	arguments = append(arguments, slices.Comprehension(args, func(idx int, value any, arr []any) reflect.Value {
+		return interfaceValue(value)
+	})...)
+	values := bw.binding.MethodByName("ExecuteWithResponse").Call(arguments)
+	val = values[0].Interface()
+	meta = values[1].Interface().(ResponseMeta)
+	err = nil
+	if !values[2].IsNil() {
+		err = values[2].Interface().(error)
+	}
+	return
+}
+
+// ExecuteNamed calls the Binding.Execute method for the underlying Binding in the BindingWrapper, using the given map of
+// BindingParam names to arguments. The named arguments are converted to positional arguments using the BindingParam(s)
+// returned by Binding.Params. A ParamError is returned if a required BindingParam is not given or if an argument is
+// given for a BindingParam that does not exist.
+func (bw BindingWrapper) ExecuteNamed(client Client, args map[string]any) (val any, err error) {
+	var positionalArgs []any
+	if positionalArgs, err = namedArgs(bw.Params(), args); err != nil {
+		return
+	}
+	return bw.Execute(client, positionalArgs...)
+}
+
+// withAttrs returns a copy of the BindingWrapper whose underlying Binding has its own copy of the Attr(s) of the
+// original Binding, with the given Attr(s) added. The original Binding is not modified.
+func (bw BindingWrapper) withAttrs(attrs ...Attr) BindingWrapper {
+	binding, ok := bw.binding.Interface().(interface{ withOwnAttrs(attrs ...Attr) any })
+	if !ok {
+		// Binding(s) that are not implemented by bindingProto cannot be copied, so the Attr(s) are added to them in place
+		bw.binding = bw.binding.MethodByName("AddAttrs").Call(slices.Comprehension(attrs, func(idx int, value Attr, arr []Attr) reflect.Value {
+			return reflect.ValueOf(value)
+		}))[0]
+		return bw
+	}
+
+	copied := reflect.New(bw.binding.Type()).Elem()
+	copied.Set(reflect.ValueOf(binding.withOwnAttrs(attrs...)))
+	bw.binding = copied
+	return bw
+}
+
+// setName returns a copy of the BindingWrapper where both the BindingWrapper and its underlying Binding have the given
+// name.
+func (bw BindingWrapper) setName(name string) BindingWrapper {
+	bw.name = name
+	bw.binding = bw.binding.MethodByName("SetName").Call([]reflect.Value{reflect.ValueOf(name)})[0]
+	return bw
+}
+
+// bindingTypesKey is the key used to cache the bindingTypes of a Binding[ResT, RetT] within bindingTypesCache.
+type bindingTypesKey[ResT any, RetT any] struct{}
+
+// bindingTypes holds the reflect.Type(s) of the ResT and RetT type parameters of a Binding.
+type bindingTypes struct {
+	responseType reflect.Type
+	returnType   reflect.Type
+}
+
+// bindingTypesCache caches the bindingTypes for each instantiation of WrapBinding.
+var bindingTypesCache sync.Map
+
+// typesOfBinding returns the bindingTypes for the given type parameters. These are derived from pointers to each type
+// parameter so that interface types, which have nil zero values, are still resolved to a non-nil reflect.Type.
+func typesOfBinding[ResT any, RetT any]() bindingTypes {
+	key := bindingTypesKey[ResT, RetT]{}
+	if types, ok := bindingTypesCache.Load(key); ok {
+		return types.(bindingTypes)
+	}
+
+	types := bindingTypes{
+		responseType: reflect.TypeOf((*ResT)(nil)).Elem(),
+		returnType:   reflect.TypeOf((*RetT)(nil)).Elem(),
+	}
+	bindingTypesCache.Store(key, types)
+	return types
 }
 
 // WrapBinding will return the BindingWrapper for the given Binding. The name of the BindingWrapper will be fetched from
 // Binding.Name, so make sure to override this before using the Binding.
 func WrapBinding[ResT any, RetT any](binding Binding[ResT, RetT]) BindingWrapper {
-	var (
-		resT ResT
-		retT RetT
-	)
+	types := typesOfBinding[ResT, RetT]()
 	return BindingWrapper{
 		name:         binding.Name(),
-		responseType: reflect.TypeOf(resT),
-		returnType:   reflect.TypeOf(retT),
+		responseType: types.responseType,
+		returnType:   types.returnType,
 		binding:      reflect.ValueOf(&binding).Elem(),
 	}
 }
@@ -171,22 +435,221 @@ func WrapBinding[ResT any, RetT any](binding Binding[ResT, RetT]) BindingWrapper
 // Schema is a mapping of names to BindingWrapper(s).
 type Schema map[string]BindingWrapper
 
+// Clone returns a shallow copy of the Schema. The BindingWrapper(s) within the copy will still refer to the same
+// underlying Binding(s).
+func (s Schema) Clone() Schema {
+	clone := make(Schema, len(s))
+	for bindingName, bindingWrapper := range s {
+		clone[bindingName] = bindingWrapper
+	}
+	return clone
+}
+
+// MergeSchemas combines the given Schema(s) into a new Schema. The name of each BindingWrapper in the merged Schema is
+// set to its key. An error is returned if the same key exists in more than one of the given Schema(s).
+func MergeSchemas(schemas ...Schema) (Schema, error) {
+	merged := make(Schema)
+	for schemaNo, schema := range schemas {
+		for bindingName, bindingWrapper := range schema {
+			if _, ok := merged[bindingName]; ok {
+				return nil, fmt.Errorf(
+					"cannot merge Schema no. %d as Binding %q already exists in a previous Schema",
+					schemaNo+1, bindingName,
+				)
+			}
+			bindingWrapper.name = bindingName
+			merged[bindingName] = bindingWrapper
+		}
+	}
+	return merged, nil
+}
+
+// SchemaBuilder builds a Schema by registering each Binding under a name that is only given once, which avoids
+// duplicating the name in both the key of the Schema and the call to Binding.SetName/NewWrappedBinding. Use
+// NewSchemaBuilder to create a SchemaBuilder, and SchemaBuilder.Build to build the Schema:
+//
+//	schema, err := NewSchemaBuilder().
+//		Add("users", WrapBinding(usersBinding)).
+//		Add("products", WrapBinding(NewBindingChain(productsRequest).SetParamsMethod(productsParams))).
+//		Build()
+type SchemaBuilder struct {
+	schema Schema
+	err    error
+}
+
+// NewSchemaBuilder creates a new empty SchemaBuilder.
+func NewSchemaBuilder() *SchemaBuilder {
+	return &SchemaBuilder{schema: make(Schema)}
+}
+
+// Add registers the given BindingWrapper under the given name. Both the BindingWrapper and its underlying Binding are
+// given the name, so any name previously set using Binding.SetName is overridden. If the name has already been
+// registered, then SchemaBuilder.Build will return an error. This returns the SchemaBuilder so it can be chained.
+func (sb *SchemaBuilder) Add(name string, binding BindingWrapper) *SchemaBuilder {
+	if sb.err != nil {
+		return sb
+	}
+
+	if _, ok := sb.schema[name]; ok {
+		sb.err = fmt.Errorf("Binding %q has already been added to the Schema", name)
+		return sb
+	}
+	sb.schema[name] = binding.setName(name)
+	return sb
+}
+
+// Build returns the built Schema, or the first error that occurred whilst adding Binding(s).
+func (sb *SchemaBuilder) Build() (Schema, error) {
+	if sb.err != nil {
+		return nil, sb.err
+	}
+	return sb.schema.Clone(), nil
+}
+
+// BaseURLAttrKey is the key of the Attr that is added to each Binding within an API that was constructed with the
+// WithBaseURL APIOption. The base URL can then be retrieved from Binding.Attrs when constructing a Request.
+const BaseURLAttrKey = "baseURL"
+
+// Logger is used by an API to log the execution of Binding(s). It has the same signature as RateLimitedClient.Log, so
+// a RateLimitedClient can also be used as a Logger.
+type Logger interface {
+	Log(string)
+}
+
+// Metrics is used by an API to record metrics on the execution of Binding(s).
+type Metrics interface {
+	// Observe is called after each Binding is executed by API.Execute with the name of the Binding, the time.Duration
+	// that the execution took, and the error returned by the execution (if any).
+	Observe(bindingName string, duration time.Duration, err error)
+}
+
+// ArgInterceptor is called with the name of the Binding and the arguments passed to API.Execute/API.Paginator before
+// they are passed to the Binding. The returned arguments are used in place of the given arguments. If an error is
+// returned then the Binding will not be executed.
+type ArgInterceptor func(bindingName string, args []any) ([]any, error)
+
+// APIOption is a functional option that can be passed to NewAPI to configure the constructed API.
+type APIOption func(api *API)
+
+// WithBaseURL sets the base URL of the API. This will add an Attr under the BaseURLAttrKey to a copy of every Binding
+// within the Schema of the API, which can be used within Binding.Request to construct the URL to the resource. The
+// Binding(s) within the Schema given to NewAPI are not modified.
+func WithBaseURL(baseURL string) APIOption {
+	return func(api *API) { api.baseURL = baseURL }
+}
+
+// WithLogger sets the Logger used by the API to log each execution of a Binding.
+func WithLogger(logger Logger) APIOption {
+	return func(api *API) { api.logger = logger }
+}
+
+// WithMetrics sets the Metrics that the API will record each execution of a Binding to.
+func WithMetrics(metrics Metrics) APIOption {
+	return func(api *API) { api.metrics = metrics }
+}
+
+// WithArgInterceptor sets the ArgInterceptor that will be called on the arguments passed to API.Execute and
+// API.Paginator.
+func WithArgInterceptor(interceptor ArgInterceptor) APIOption {
+	return func(api *API) { api.argInterceptor = interceptor }
+}
+
+// WithHTTPClient sets the http.Client used by the HTTPClient of the API. If the API was constructed with a nil Client,
+// then a new HTTPClient is created for it using NewHTTPClient. This has no effect if the Client of the API is not a
+// HTTPClient. To only supply a custom http.RoundTripper, a http.Client can be given with just its Transport set:
+//
+//	api := NewAPI(nil, schema, WithHTTPClient(&http.Client{Transport: transport}))
+func WithHTTPClient(client *http.Client) APIOption {
+	return func(api *API) {
+		if api.Client == nil {
+			api.Client = NewHTTPClient()
+		}
+
+		if httpClient, ok := api.Client.(*HTTPClient); ok {
+			httpClient.SetHTTPClient(client)
+		}
+	}
+}
+
 // API represents a connection to an API with multiple different available Binding(s).
 type API struct {
-	Client Client
-	schema Schema
+	Client         Client
+	schema         Schema
+	baseURL        string
+	logger         Logger
+	metrics        Metrics
+	argInterceptor ArgInterceptor
 }
 
-// NewAPI constructs a new API instance for the given Client and Schema combination.
-func NewAPI(client Client, schema Schema) *API {
+// NewAPI constructs a new API instance for the given Client and Schema combination. Any given APIOption(s) are applied
+// to the API in order. The given Schema is cloned, so it is not modified, and each BindingWrapper (along with its
+// underlying Binding) within the clone is named after its key.
+func NewAPI(client Client, schema Schema, opts ...APIOption) *API {
+	schema = schema.Clone()
 	for bindingName, bindingWrapper := range schema {
-		bindingWrapper.name = bindingName
+		schema[bindingName] = bindingWrapper.setName(bindingName)
 	}
 
-	return &API{
+	api := &API{
 		Client: client,
 		schema: schema,
 	}
+
+	for _, opt := range opts {
+		opt(api)
+	}
+
+	// The Binding(s) are copied before the base URL is added, so that the base URL does not leak into the Binding(s) of
+	// the given Schema, or into other API(s) built from the same Schema
+	if baseURL := api.baseURL; baseURL != "" {
+		for bindingName, bindingWrapper := range api.schema {
+			api.schema[bindingName] = bindingWrapper.withAttrs(func(client Client) (string, any) { return BaseURLAttrKey, baseURL })
+		}
+	}
+	return api
+}
+
+// BaseURL returns the base URL of the API that was set using the WithBaseURL APIOption.
+func (api *API) BaseURL() string { return api.baseURL }
+
+// Decorate rebuilds the Schema of the API by replacing each BindingWrapper with the BindingWrapper returned by the
+// given decorator. This is useful for applying the same policy to every Binding, such as a RetryPolicy (see
+// BindingWrapper.With). The name of each returned BindingWrapper, along with the name of its underlying Binding, is set
+// to the name of the BindingWrapper that it replaces. This returns the API so it can be chained.
+func (api *API) Decorate(decorator func(bw BindingWrapper) BindingWrapper) *API {
+	schema := make(Schema, len(api.schema))
+	for bindingName, bindingWrapper := range api.schema {
+		schema[bindingName] = decorator(bindingWrapper).setName(bindingName)
+	}
+	api.schema = schema
+	return api
+}
+
+func (api *API) log(ctx context.Context, format string, args ...any) {
+	if api.logger != nil {
+		api.logger.Log(traceLogPrefix(ctx) + fmt.Sprintf(format, args...))
+	}
+}
+
+// clientFor returns the Client to use for the given BindingWrapper. The Client set using Binding.SetClient is preferred
+// over the Client of the API.
+func (api *API) clientFor(binding BindingWrapper) Client {
+	if client := binding.Client(); client != nil {
+		return client
+	}
+	return api.Client
+}
+
+func (api *API) interceptArgs(name string, args []any) ([]any, error) {
+	if api.argInterceptor == nil {
+		return args, nil
+	}
+
+	interceptedArgs, err := api.argInterceptor(name, args)
+	if err != nil {
+		return args, errors.Wrapf(err, "ArgInterceptor failed for Binding %q", name)
+	}
+	return interceptedArgs, nil
 }
 
 // Binding returns the BindingWrapper with the given name in the Schema for this API. The second return value is an "ok"
@@ -196,6 +659,23 @@ func (api *API) Binding(name string) (BindingWrapper, bool) {
 	return binding, ok
 }
 
+// Require returns an error listing the given names that do not have a Binding within the Schema for this API. This
+// can be used when an application starts to check that the API contains all the Binding(s) that the application relies
+// on.
+func (api *API) Require(names ...string) error {
+	missing := make([]string, 0)
+	for _, name := range names {
+		if _, ok := api.Binding(name); !ok {
+			missing = append(missing, strconv.Quote(name))
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("API is missing %d required Binding(s): %s", len(missing), strings.Join(missing, ", "))
+	}
+	return nil
+}
+
 func (api *API) checkBindingExists(name string) (binding BindingWrapper, err error) {
 	var ok bool
 	if binding, ok = api.Binding(name); !ok {
@@ -215,18 +695,100 @@ func (api *API) ArgsFromStrings(name string, args ...string) (parsedArgs []any,
 
 // Execute will execute the Binding of the given name within the API.
 func (api *API) Execute(name string, args ...any) (val any, err error) {
+	return api.ExecuteCtx(context.Background(), name, args...)
+}
+
+// ExecuteCtx will execute the Binding of the given name within the API using the given context.Context.
+func (api *API) ExecuteCtx(ctx context.Context, name string, args ...any) (val any, err error) {
 	var binding BindingWrapper
 	if binding, err = api.checkBindingExists(name); err != nil {
 		return
 	}
-	return binding.Execute(api.Client, args...)
+
+	if args, err = api.interceptArgs(name, args); err != nil {
+		return
+	}
+
+	api.log(ctx, "Executing Binding %q with args %v", name, redactArgs(binding.Params(), args))
+	start := time.Now()
+	val, err = binding.ExecuteCtx(ctx, api.clientFor(binding), args...)
+	if api.metrics != nil {
+		api.metrics.Observe(name, time.Since(start), err)
+	}
+
+	if err != nil {
+		api.log(ctx, "Binding %q failed: %v", name, err)
+	}
+	return
+}
+
+// ExecuteInto will execute the Binding of the given name within the API in the same way as Execute, then assign the
+// result to the value pointed to by out. An error is returned without executing the Binding if out is not a non-nil
+// pointer, or if the return type of the Binding cannot be assigned to the value pointed to by out.
+func (api *API) ExecuteInto(name string, out any, args ...any) (err error) {
+	outVal := reflect.ValueOf(out)
+	if outVal.Kind() != reflect.Pointer || outVal.IsNil() {
+		return fmt.Errorf("cannot execute Binding %q into %T, as it is not a non-nil pointer", name, out)
+	}
+
+	var binding BindingWrapper
+	if binding, err = api.checkBindingExists(name); err != nil {
+		return
+	}
+
+	target := outVal.Elem()
+	if binding.returnType != nil && binding.returnType.Kind() != reflect.Interface && !binding.returnType.AssignableTo(target.Type()) {
+		return fmt.Errorf("cannot assign result of Binding %q of type %v to %v", name, binding.returnType, target.Type())
+	}
+
+	var val any
+	if val, err = api.Execute(name, args...); err != nil {
+		return
+	}
+
+	if val == nil {
+		target.Set(reflect.Zero(target.Type()))
+		return
+	}
+
+	result := reflect.ValueOf(val)
+	if !result.Type().AssignableTo(target.Type()) {
+		return fmt.Errorf("cannot assign result of Binding %q of type %v to %v", name, result.Type(), target.Type())
+	}
+	target.Set(result)
+	return
+}
+
+// ExecuteNamed will execute the Binding of the given name within the API using the given named arguments. See
+// BindingWrapper.ExecuteNamed for more information.
+func (api *API) ExecuteNamed(name string, args map[string]any) (val any, err error) {
+	var binding BindingWrapper
+	if binding, err = api.checkBindingExists(name); err != nil {
+		return
+	}
+
+	var positionalArgs []any
+	if positionalArgs, err = namedArgs(binding.Params(), args); err != nil {
+		return
+	}
+	return api.Execute(name, positionalArgs...)
 }
 
 // Paginator returns a Paginator for the Binding of the given name within the API.
 func (api *API) Paginator(name string, waitTime time.Duration, args ...any) (paginator Paginator[any, any], err error) {
+	return api.PaginatorCtx(context.Background(), name, waitTime, args...)
+}
+
+// PaginatorCtx returns a Paginator for the Binding of the given name within the API, that will execute the Binding
+// using the given context.Context.
+func (api *API) PaginatorCtx(ctx context.Context, name string, waitTime time.Duration, args ...any) (paginator Paginator[any, any], err error) {
 	var binding BindingWrapper
 	if binding, err = api.checkBindingExists(name); err != nil {
 		return
 	}
-	return NewPaginator(api.Client, waitTime, binding, args...)
+
+	if args, err = api.interceptArgs(name, args); err != nil {
+		return
+	}
+	return NewPaginatorCtx(ctx, api.clientFor(binding), waitTime, binding, args...)
 }
@@ -15,6 +15,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 )
 
 type httpClient struct {
@@ -45,6 +46,45 @@ func (h httpClient) Run(ctx context.Context, bindingName string, attrs map[strin
 	return
 }
 
+// mockRequest is a Request that is used by the mockClient. It holds the arguments that were passed to Binding.Request.
+type mockRequest struct {
+	args   []any
+	header http.Header
+}
+
+func (req *mockRequest) Header() *http.Header {
+	if req.header == nil {
+		req.header = make(http.Header)
+	}
+	return &req.header
+}
+
+// mockClient is a Client that calls the run function to produce the response for a mockRequest. The response is
+// marshalled to JSON and unmarshalled into the response wrapper, like a Client that talks to a real API would.
+type mockClient struct {
+	run      func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error)
+	requests int
+}
+
+func (m *mockClient) Run(ctx context.Context, bindingName string, attrs map[string]any, req Request, res any) (err error) {
+	m.requests++
+	var response any
+	if response, err = m.run(ctx, bindingName, attrs, req); err != nil {
+		return
+	}
+
+	var body []byte
+	if body, err = json.Marshal(response); err != nil {
+		return
+	}
+	return json.Unmarshal(body, res)
+}
+
+// mockRequestMethod is a BindingRequestMethod that returns a mockRequest containing the given args.
+func mockRequestMethod[ResT any, RetT any](binding Binding[ResT, RetT], args ...any) Request {
+	return &mockRequest{args: args}
+}
+
 func TestParams(t *testing.T) {
 	var args []any
 	var testNo int
@@ -342,6 +382,34 @@ func TestBindingProto_TypeCheckArgs(t *testing.T) {
 				errors.New(""),
 			},
 		},
+		{
+			params: Params(
+				"separator", "", true,
+				"stringers", []fmt.Stringer{}, false, true,
+			),
+			inputArgs: [][]any{
+				// Concrete types that implement fmt.Stringer can be passed as variadic arguments
+				{",", time.Second, time.Minute},
+				// Heterogeneous concrete types that all implement fmt.Stringer
+				{",", time.Second, reflect.Int, time.UTC},
+				// No variadic arguments
+				{","},
+				// An argument that does not implement fmt.Stringer
+				{",", time.Second, 1},
+			},
+			expectedArgs: [][]any{
+				{",", time.Second, time.Minute},
+				{",", time.Second, reflect.Int, time.UTC},
+				{","},
+				{",", time.Second},
+			},
+			errs: []error{
+				errors.New(""),
+				errors.New(""),
+				errors.New(""),
+				errors.New("variadic param \"stringers\"'s element type (fmt.Stringer) does not match arg no. 1's type (int)"),
+			},
+		},
 	} {
 		binding := NewBindingChain[bool, bool](func(binding Binding[bool, bool], args ...any) (request Request) {
 			return HTTPRequest{nil}
@@ -493,17 +561,20 @@ func ExampleNewAPI() {
 				return Params("limit", 1)
 			}, false,
 		),
-		// The "first_product" Binding showcases how to set the response method, as well as how to use the chaining API
-		// when creating Bindings. This will execute a similar HTTP request to the "products" Binding but
-		// Binding.Execute will instead return a single Product instance. We can also add more attributes to the Binding
-		// which we can access at any point from the Binding instance.
+		// The "first_product" Binding showcases how to set a response method that can return an error, as well as how
+		// to use the chaining API when creating Bindings. This will execute a similar HTTP request to the "products"
+		// Binding but Binding.Execute will instead return a single Product instance. We can also add more attributes to
+		// the Binding which we can access at any point from the Binding instance.
 		// Note: how the RetT type param is set to just "Product".
 		"first_product": WrapBinding(NewBindingChain(func(binding Binding[[]Product, Product], args ...any) (request Request) {
 			client := binding.Attrs()["client"].(httpClient)
 			req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/products?limit=1", client.URL), nil)
 			return HTTPRequest{req}
-		}).SetResponseMethod(func(binding Binding[[]Product, Product], response []Product, args ...any) Product {
-			return response[0]
+		}).SetResponseMethodE(func(binding Binding[[]Product, Product], response []Product, args ...any) (Product, error) {
+			if len(response) == 0 {
+				return Product{}, fmt.Errorf("no products were returned")
+			}
+			return response[0], nil
 		}).SetName("first_product").AddAttrs(func(client Client) (string, any) {
 			return "client", client.(httpClient)
 		})),
@@ -544,3 +615,442 @@ func ExampleNewAPI() {
 	// {1 Fjallraven - Foldsack No. 1 Backpack, Fits 15 Laptops 109.95 men's clothing Your perfect pack for everyday use and walks in the forest. Stash your laptop (up to 15 inches) in the padded sleeve, your everyday https://fakestoreapi.com/img/81fPKd-2AYL._AC_SL1500_.jpg}
 	// [{1 john@gmail.com johnd m38rmF$ {john doe} {kilcoole new road 7682 12926-3874 {-37.3159 81.1496}} 1-570-236-7033}]
 }
+
+type recordingLogger []string
+
+func (l *recordingLogger) Log(msg string) { *l = append(*l, msg) }
+
+type recordingMetrics map[string]int
+
+func (m recordingMetrics) Observe(bindingName string, duration time.Duration, err error) {
+	m[bindingName]++
+}
+
+func TestNewAPI_Options(t *testing.T) {
+	logger := &recordingLogger{}
+	metrics := make(recordingMetrics)
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		return fmt.Sprintf("%s/%v", attrs[BaseURLAttrKey], req.(*mockRequest).args[0]), nil
+	}}
+
+	api := NewAPI(client, Schema{
+		"echo": WrapBinding(NewBindingChain(mockRequestMethod[string, string]).SetParamsMethod(func(binding Binding[string, string]) []BindingParam {
+			return Params("path", "", true)
+		}).SetName("echo")),
+	},
+		WithBaseURL("https://example.com"),
+		WithLogger(logger),
+		WithMetrics(metrics),
+		WithArgInterceptor(func(bindingName string, args []any) ([]any, error) {
+			if len(args) == 0 {
+				return nil, errors.New("no args")
+			}
+			return []any{strings.ToUpper(args[0].(string))}, nil
+		}),
+	)
+
+	if api.BaseURL() != "https://example.com" {
+		t.Errorf("expected base URL to be %q, not %q", "https://example.com", api.BaseURL())
+	}
+
+	resp, err := api.Execute("echo", "users")
+	if err != nil {
+		t.Fatalf("could not execute \"echo\": %v", err)
+	}
+
+	if expected := "https://example.com/USERS"; resp.(string) != expected {
+		t.Errorf("expected response %q, not %q", expected, resp)
+	}
+
+	if _, err = api.Execute("echo"); err == nil || !strings.Contains(err.Error(), "no args") {
+		t.Errorf("expected ArgInterceptor error, got %v", err)
+	}
+
+	if metrics["echo"] != 1 {
+		t.Errorf("expected 1 execution of \"echo\" to be observed, not %d", metrics["echo"])
+	}
+
+	if len(*logger) != 1 || !strings.Contains((*logger)[0], "[USERS]") {
+		t.Errorf("expected a single log message containing the intercepted args, got %q", *logger)
+	}
+}
+
+func TestAPI_ExecuteCtx(t *testing.T) {
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+			return true, nil
+		}
+	}}
+
+	api := NewAPI(client, Schema{
+		"slow": WrapBinding(NewBindingChain(mockRequestMethod[bool, bool]).SetName("slow")),
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := api.ExecuteCtx(ctx, "slow"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected error to be context.DeadlineExceeded, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Errorf("ExecuteCtx did not return when the context was cancelled (took %s)", elapsed)
+	}
+
+	// A context that is already cancelled should not reach the Client
+	cancelledCtx, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	if _, err := api.ExecuteCtx(cancelledCtx, "slow"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected error to be context.Canceled, got %v", err)
+	}
+
+	if client.requests != 1 {
+		t.Errorf("expected 1 request to be made, not %d", client.requests)
+	}
+
+	// Paginators created with PaginatorCtx should also propagate the cancellation
+	api = NewAPI(cappedPageClient(10, 2), Schema{"paged": WrapBinding(pagedIntBinding().SetName("paged"))})
+	paginator, err := api.PaginatorCtx(cancelledCtx, "paged", 0)
+	if err != nil {
+		t.Fatalf("could not create paginator: %v", err)
+	}
+
+	if _, err = paginator.All(); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected Paginator.All error to be context.Canceled, got %v", err)
+	}
+}
+
+func TestWrapBinding_InterfaceTypes(t *testing.T) {
+	stringerType := reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	for testNo, test := range []struct {
+		binding              BindingWrapper
+		expectedResponseType reflect.Type
+		expectedReturnType   reflect.Type
+	}{
+		{
+			binding:              WrapBinding(NewBindingChain(mockRequestMethod[fmt.Stringer, fmt.Stringer])),
+			expectedResponseType: stringerType,
+			expectedReturnType:   stringerType,
+		},
+		{
+			binding:              WrapBinding(NewBindingChain(mockRequestMethod[any, []int])),
+			expectedResponseType: reflect.TypeOf((*any)(nil)).Elem(),
+			expectedReturnType:   reflect.TypeOf([]int{}),
+		},
+		{
+			// The types of this Binding should be fetched from the cache
+			binding:              WrapBinding(NewBindingChain(mockRequestMethod[fmt.Stringer, fmt.Stringer])),
+			expectedResponseType: stringerType,
+			expectedReturnType:   stringerType,
+		},
+	} {
+		if test.binding.responseType != test.expectedResponseType {
+			t.Errorf("test no. %d expected response type %v, not %v", testNo+1, test.expectedResponseType, test.binding.responseType)
+		}
+
+		if test.binding.returnType != test.expectedReturnType {
+			t.Errorf("test no. %d expected return type %v, not %v", testNo+1, test.expectedReturnType, test.binding.returnType)
+		}
+	}
+}
+
+func TestMergeSchemas(t *testing.T) {
+	users := Schema{
+		"users": WrapBinding(NewBindingChain(mockRequestMethod[[]int, []int]).SetName("listUsers")),
+		"user":  WrapBinding(NewBindingChain(mockRequestMethod[int, int])),
+	}
+	products := Schema{
+		"products": WrapBinding(NewBindingChain(mockRequestMethod[[]int, []int])),
+	}
+
+	merged, err := MergeSchemas(users, products)
+	if err != nil {
+		t.Fatalf("could not merge Schemas: %v", err)
+	}
+
+	if len(merged) != 3 {
+		t.Errorf("expected merged Schema to contain 3 Bindings, not %d", len(merged))
+	}
+
+	for bindingName, bindingWrapper := range merged {
+		if bindingWrapper.Name() != bindingName {
+			t.Errorf("expected BindingWrapper for %q to be named %q, not %q", bindingName, bindingName, bindingWrapper.Name())
+		}
+	}
+
+	// Modifying the clone should not modify the original
+	clone := merged.Clone()
+	delete(clone, "users")
+	if _, ok := merged["users"]; !ok {
+		t.Errorf("deleting from a cloned Schema modified the original Schema")
+	}
+
+	if _, err = MergeSchemas(users, products, Schema{"user": users["user"]}); err == nil {
+		t.Errorf("expected an error when merging Schemas with colliding keys")
+	} else if expected := "cannot merge Schema no. 3 as Binding \"user\" already exists in a previous Schema"; err.Error() != expected {
+		t.Errorf("expected error %q, not %q", expected, err.Error())
+	}
+}
+
+func TestBindingProto_SetClient(t *testing.T) {
+	clientFor := func(name string) *mockClient {
+		return &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+			return name, nil
+		}}
+	}
+	mainClient, authClient := clientFor("main"), clientFor("auth")
+
+	api := NewAPI(mainClient, Schema{
+		"items": WrapBinding(NewBindingChain(mockRequestMethod[string, string])),
+		"token": WrapBinding(NewBindingChain(mockRequestMethod[string, string]).SetClient(authClient)),
+	})
+
+	for testNo, test := range []struct {
+		binding  string
+		expected string
+	}{
+		{"items", "main"},
+		{"token", "auth"},
+	} {
+		actual, err := api.Execute(test.binding)
+		if err != nil {
+			t.Errorf("test no. %d could not execute Binding %q: %v", testNo+1, test.binding, err)
+		} else if actual.(string) != test.expected {
+			t.Errorf("test no. %d expected Binding %q to use the %q Client, not the %q Client", testNo+1, test.binding, test.expected, actual)
+		}
+	}
+
+	if mainClient.requests != 1 || authClient.requests != 1 {
+		t.Errorf("expected each Client to be used once, got %d and %d requests", mainClient.requests, authClient.requests)
+	}
+
+	// Executing a Binding directly with a nil Client will use the Binding's Client
+	binding := NewBindingChain(mockRequestMethod[string, string])
+	if actual, err := binding.SetClient(authClient).Execute(nil); err != nil || actual != "auth" {
+		t.Errorf("expected Execute with a nil Client to return (\"auth\", nil), not (%q, %v)", actual, err)
+	}
+
+	if _, err := binding.Execute(nil); err == nil {
+		t.Errorf("expected an error when executing a Binding with no Client")
+	}
+}
+
+func TestSchemaBuilder(t *testing.T) {
+	schema, err := NewSchemaBuilder().
+		Add("users", WrapBinding(NewBindingChain(mockRequestMethod[[]int, []int]))).
+		Add("user", WrapBinding(NewBindingChain(mockRequestMethod[int, int]).SetName("getUser"))).
+		Build()
+	if err != nil {
+		t.Fatalf("could not build Schema: %v", err)
+	}
+
+	if len(schema) != 2 {
+		t.Errorf("expected Schema to contain 2 Bindings, not %d", len(schema))
+	}
+
+	for bindingName, bindingWrapper := range schema {
+		if bindingWrapper.Name() != bindingName {
+			t.Errorf("expected BindingWrapper for %q to be named %q, not %q", bindingName, bindingName, bindingWrapper.Name())
+		}
+
+		// The underlying Binding should also be named, so that Client.Run receives the name
+		var names []string
+		client := &mockClient{run: func(ctx context.Context, name string, attrs map[string]any, req Request) (any, error) {
+			names = append(names, name)
+			return 1, nil
+		}}
+		_, _ = bindingWrapper.Execute(client)
+		if !reflect.DeepEqual(names, []string{bindingName}) {
+			t.Errorf("expected Client.Run to receive the name %q, not %q", bindingName, names)
+		}
+	}
+
+	if _, err = NewSchemaBuilder().
+		Add("user", WrapBinding(NewBindingChain(mockRequestMethod[int, int]))).
+		Add("user", WrapBinding(NewBindingChain(mockRequestMethod[int, int]))).
+		Build(); err == nil {
+		t.Errorf("expected an error when adding a duplicate Binding")
+	} else if expected := "Binding \"user\" has already been added to the Schema"; err.Error() != expected {
+		t.Errorf("expected error %q, not %q", expected, err.Error())
+	}
+}
+
+// countingClient is a Client that counts the number of times each Binding is run by its inner Client.
+type countingClient struct {
+	Client
+	counts map[string]int
+}
+
+func (c countingClient) Run(ctx context.Context, bindingName string, attrs map[string]any, req Request, res any) error {
+	c.counts[bindingName]++
+	return c.Client.Run(ctx, bindingName, attrs, req, res)
+}
+
+func TestAPI_Decorate(t *testing.T) {
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		return 1, nil
+	}}
+
+	api := NewAPI(client, Schema{
+		"users":    WrapBinding(NewBindingChain(mockRequestMethod[int, int]).SetName("users")),
+		"products": WrapBinding(NewBindingChain(mockRequestMethod[int, int]).SetName("products")),
+	})
+
+	counter := countingClient{Client: client, counts: make(map[string]int)}
+	api.Decorate(func(bw BindingWrapper) BindingWrapper {
+		decorated, err := bw.With("SetClient", counter)
+		if err != nil {
+			t.Fatalf("could not decorate Binding %q: %v", bw.Name(), err)
+		}
+		return decorated
+	})
+
+	for _, name := range []string{"users", "users", "products"} {
+		if _, err := api.Execute(name); err != nil {
+			t.Errorf("could not execute Binding %q: %v", name, err)
+		}
+	}
+
+	if expected := map[string]int{"users": 2, "products": 1}; !reflect.DeepEqual(counter.counts, expected) {
+		t.Errorf("expected execution counts %v, not %v", expected, counter.counts)
+	}
+
+	binding, _ := api.Binding("users")
+	if _, err := binding.With("SetNothing"); err == nil {
+		t.Errorf("expected an error when calling a method that does not exist")
+	}
+
+	if _, err := binding.With("Name"); err == nil {
+		t.Errorf("expected an error when calling a method that does not return a Binding")
+	}
+}
+
+func TestAPI_Require(t *testing.T) {
+	api := NewAPI(&mockClient{}, Schema{
+		"users": WrapBinding(NewBindingChain(mockRequestMethod[[]int, []int])),
+		"user":  WrapBinding(NewBindingChain(mockRequestMethod[int, int])),
+	})
+
+	if err := api.Require("users", "user"); err != nil {
+		t.Errorf("expected no error when all Bindings exist, got %v", err)
+	}
+
+	expected := `API is missing 1 required Binding(s): "products"`
+	if err := api.Require("users", "products", "user"); err == nil || err.Error() != expected {
+		t.Errorf("expected error %q, not %v", expected, err)
+	}
+}
+
+func TestAPI_ExecuteInto(t *testing.T) {
+	type user struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		return map[string]any{"id": 1, "name": "andy"}, nil
+	}}
+	api := NewAPI(client, Schema{"user": WrapBinding(NewBindingChain(mockRequestMethod[user, user]))})
+
+	var u user
+	if err := api.ExecuteInto("user", &u); err != nil {
+		t.Fatalf("could not execute \"user\" into *user: %v", err)
+	}
+
+	if expected := (user{ID: 1, Name: "andy"}); u != expected {
+		t.Errorf("expected %+v, not %+v", expected, u)
+	}
+
+	var wrong string
+	expected := `cannot assign result of Binding "user" of type api.user to string`
+	if err := api.ExecuteInto("user", &wrong); err == nil || err.Error() != expected {
+		t.Errorf("expected error %q, not %v", expected, err)
+	}
+
+	if err := api.ExecuteInto("user", u); err == nil {
+		t.Errorf("expected an error when out is not a pointer")
+	}
+
+	if client.requests != 1 {
+		t.Errorf("expected mismatched ExecuteInto calls to not execute the Binding, but %d requests were made", client.requests)
+	}
+}
+
+func TestNewAPI_DoesNotModifySchema(t *testing.T) {
+	var bindingNames []string
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		bindingNames = append(bindingNames, bindingName)
+		return 1, nil
+	}}
+
+	schema := Schema{"users": WrapBinding(NewBindingChain(mockRequestMethod[int, int]).SetName("people"))}
+	api := NewAPI(client, schema)
+
+	if name := schema["users"].Name(); name != "people" {
+		t.Errorf("expected NewAPI not to rename the BindingWrapper within the given Schema, but it was renamed to %q", name)
+	}
+
+	if _, err := api.Execute("users"); err != nil {
+		t.Fatalf("could not execute \"users\": %v", err)
+	}
+
+	if expected := []string{"users"}; !reflect.DeepEqual(bindingNames, expected) {
+		t.Errorf("expected Client.Run to be called with the Binding names %v, not %v", expected, bindingNames)
+	}
+}
+
+func TestWithBaseURL(t *testing.T) {
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		baseURL, _ := attrs[BaseURLAttrKey].(string)
+		return baseURL, nil
+	}}
+
+	binding := NewBindingChain(mockRequestMethod[string, string])
+	schema := Schema{"base": WrapBinding(binding)}
+	first := NewAPI(client, schema, WithBaseURL("https://first.example.com"))
+	second := NewAPI(client, schema, WithBaseURL("https://second.example.com"))
+
+	for _, test := range []struct {
+		api      *API
+		expected string
+	}{
+		{first, "https://first.example.com"},
+		{second, "https://second.example.com"},
+	} {
+		if baseURL, err := test.api.Execute("base"); err != nil {
+			t.Errorf("could not execute \"base\": %v", err)
+		} else if baseURL != test.expected {
+			t.Errorf("expected the base URL %q, not %q", test.expected, baseURL)
+		}
+	}
+
+	if _, ok := binding.Attrs()[BaseURLAttrKey]; ok {
+		t.Errorf("expected the base URL not to be added to the Binding within the given Schema")
+	}
+}
+
+func TestAPI_Decorate_Name(t *testing.T) {
+	var bindingNames []string
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		bindingNames = append(bindingNames, bindingName)
+		return 1, nil
+	}}
+
+	api := NewAPI(client, Schema{"users": WrapBinding(NewBindingChain(mockRequestMethod[int, int]))})
+	api.Decorate(func(bw BindingWrapper) BindingWrapper {
+		// The decorator re-wraps the Binding under a different name
+		return WrapBinding(NewBindingChain(mockRequestMethod[int, int]).SetName("decorated"))
+	})
+
+	if _, err := api.Execute("users"); err != nil {
+		t.Fatalf("could not execute \"users\": %v", err)
+	}
+
+	if expected := []string{"users"}; !reflect.DeepEqual(bindingNames, expected) {
+		t.Errorf("expected Client.Run to be called with the Binding names %v, not %v", expected, bindingNames)
+	}
+}
@@ -0,0 +1,132 @@
+// Package apitest provides utilities for testing Binding(s) and Paginator(s) that are created using the api package.
+package apitest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+)
+
+// Scheme is the pagination scheme that is served by a PaginatorTestServer.
+type Scheme int
+
+const (
+	// PageScheme paginates using a 1-based "page" query param. Each page is served as a JSON array of items.
+	PageScheme Scheme = iota
+	// AfterScheme paginates using an "after" query param, which is a cursor to the next page. Each page is served as a
+	// JSON object of the form:
+	//
+	//	{"items": [...], "after": "<cursor to the next page, or empty if this is the last page>"}
+	//
+	// An empty or missing "after" query param refers to the first page.
+	AfterScheme
+	// OffsetScheme paginates using a 0-based "offset" query param, which is the index of the first item on the page.
+	// Each page is served as a JSON array of items.
+	OffsetScheme
+)
+
+// String returns the name of the query param that is used to paginate for the Scheme.
+func (s Scheme) String() string {
+	switch s {
+	case PageScheme:
+		return "page"
+	case AfterScheme:
+		return "after"
+	case OffsetScheme:
+		return "offset"
+	default:
+		return "<nil>"
+	}
+}
+
+// AfterPage is the JSON object that is served for each page by a PaginatorTestServer using the AfterScheme.
+type AfterPage[Item any] struct {
+	Items []Item `json:"items"`
+	After string `json:"after"`
+}
+
+// PaginatorTestServer is a httptest.Server that serves canned pages of items using a pagination Scheme. Each page
+// contains at most PageSize items, unless a smaller "limit" query param is given.
+type PaginatorTestServer[Item any] struct {
+	*httptest.Server
+	Scheme   Scheme
+	Items    []Item
+	PageSize int
+	requests atomic.Int64
+}
+
+// NewPaginatorTestServer starts and returns a new PaginatorTestServer that serves the given items using the given
+// Scheme. The caller should call Close when finished to shut it down.
+func NewPaginatorTestServer[Item any](scheme Scheme, items []Item, pageSize int) *PaginatorTestServer[Item] {
+	s := &PaginatorTestServer[Item]{
+		Scheme:   scheme,
+		Items:    items,
+		PageSize: pageSize,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Requests returns the number of requests that have been served by the PaginatorTestServer.
+func (s *PaginatorTestServer[Item]) Requests() int { return int(s.requests.Load()) }
+
+// queryInt returns the int value of the given query param, or the given default if the query param is empty.
+func queryInt(r *http.Request, key string, def int) (int, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return def, nil
+	}
+	return strconv.Atoi(value)
+}
+
+func (s *PaginatorTestServer[Item]) serve(w http.ResponseWriter, r *http.Request) {
+	s.requests.Add(1)
+	limit, err := queryInt(r, "limit", s.PageSize)
+	if err != nil || limit <= 0 {
+		http.Error(w, "invalid limit", http.StatusBadRequest)
+		return
+	}
+
+	if limit > s.PageSize {
+		limit = s.PageSize
+	}
+
+	// Find the index of the first item on the requested page
+	var start int
+	switch s.Scheme {
+	case PageScheme:
+		var page int
+		if page, err = queryInt(r, "page", 1); err == nil && page < 1 {
+			err = strconv.ErrRange
+		}
+		start = (page - 1) * limit
+	case AfterScheme:
+		start, err = queryInt(r, "after", 0)
+	case OffsetScheme:
+		start, err = queryInt(r, "offset", 0)
+	}
+
+	if err != nil || start < 0 {
+		http.Error(w, "invalid "+s.Scheme.String(), http.StatusBadRequest)
+		return
+	}
+
+	items := make([]Item, 0, limit)
+	for i := start; i < start+limit && i < len(s.Items); i++ {
+		items = append(items, s.Items[i])
+	}
+
+	var response any = items
+	if s.Scheme == AfterScheme {
+		page := AfterPage[Item]{Items: items}
+		if start+limit < len(s.Items) {
+			page.After = strconv.Itoa(start + limit)
+		}
+		response = page
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
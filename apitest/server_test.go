@@ -0,0 +1,109 @@
+package apitest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	api "github.com/andygello555/gapi"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// jsonClient is an api.Client that executes api.HTTPRequest(s) and unmarshals the JSON response.
+type jsonClient struct{}
+
+func (jsonClient) Run(ctx context.Context, bindingName string, attrs map[string]any, req api.Request, res any) error {
+	response, err := http.DefaultClient.Do(req.(api.HTTPRequest).Request.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", response.Status)
+	}
+	return json.NewDecoder(response.Body).Decode(res)
+}
+
+// fetch fetches and decodes the JSON response from the given URL into a new instance of T.
+func fetch[T any](t *testing.T, url string) (response T, status int) {
+	t.Helper()
+	r, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("could not GET %s: %v", url, err)
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode == http.StatusOK {
+		if err = json.NewDecoder(r.Body).Decode(&response); err != nil {
+			t.Fatalf("could not decode response from %s: %v", url, err)
+		}
+	}
+	return response, r.StatusCode
+}
+
+func TestPaginatorTestServer_Schemes(t *testing.T) {
+	items := []int{0, 1, 2, 3, 4, 5, 6}
+	for testNo, test := range []struct {
+		scheme   Scheme
+		path     string
+		expected any
+	}{
+		{PageScheme, "/?page=2", []int{3, 4, 5}},
+		{PageScheme, "/?page=2&limit=2", []int{2, 3}},
+		{PageScheme, "/?page=4", []int{}},
+		{OffsetScheme, "/?offset=5", []int{5, 6}},
+		{OffsetScheme, "/?offset=1&limit=10", []int{1, 2, 3}},
+		{AfterScheme, "/", AfterPage[int]{Items: []int{0, 1, 2}, After: "3"}},
+		{AfterScheme, "/?after=6", AfterPage[int]{Items: []int{6}}},
+	} {
+		server := NewPaginatorTestServer(test.scheme, items, 3)
+		var actual any
+		if test.scheme == AfterScheme {
+			actual, _ = fetch[AfterPage[int]](t, server.URL+test.path)
+		} else {
+			actual, _ = fetch[[]int](t, server.URL+test.path)
+		}
+		server.Close()
+
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("test no. %d (%s %s) expected %v, not %v", testNo+1, test.scheme, test.path, test.expected, actual)
+		}
+	}
+
+	server := NewPaginatorTestServer(PageScheme, items, 3)
+	defer server.Close()
+	if _, status := fetch[[]int](t, server.URL+"/?page=0"); status != http.StatusBadRequest {
+		t.Errorf("expected status %d for invalid page, not %d", http.StatusBadRequest, status)
+	}
+}
+
+func TestPaginatorTestServer_Paginator(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e"}
+	server := NewPaginatorTestServer(PageScheme, items, 2)
+	defer server.Close()
+
+	binding := api.NewRESTBinding[[]string, []string](http.MethodGet, server.URL, func(binding api.Binding[[]string, []string]) []api.BindingParam {
+		return api.Params("page", 1, true, "limit", 2)
+	}, true)
+
+	paginator, err := api.NewTypedPaginator(jsonClient{}, 0, binding)
+	if err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	var actual []string
+	if actual, err = paginator.All(); err != nil {
+		t.Fatalf("could not fetch all pages: %v", err)
+	}
+
+	if !reflect.DeepEqual(actual, items) {
+		t.Errorf("expected items %v, not %v", items, actual)
+	}
+
+	// The last page is shorter than the page size, so no empty page should be requested
+	if server.Requests() != 3 {
+		t.Errorf("expected 3 requests, not %d", server.Requests())
+	}
+}
@@ -0,0 +1,23 @@
+package api
+
+import (
+	"encoding/base64"
+)
+
+// BearerAuth returns a header Attr that sets the Authorization header of each Request constructed by a Binding to the
+// given bearer token. For tokens that change over time, use HeaderAttr with a func() string value instead, which is
+// called each time the Binding is executed. For tokens that are held by the Client, use an Attr closure that reads the
+// token from the Client, which will be evaluated once the Binding is executed with a Client:
+//
+//	func(client Client) (string, any) {
+//		return HeaderAttrPrefix + "Authorization", "Bearer " + client.(*myClient).Token()
+//	}
+func BearerAuth(token string) Attr {
+	return HeaderAttr("Authorization", "Bearer "+token)
+}
+
+// BasicAuth returns a header Attr that sets the Authorization header of each Request constructed by a Binding to the
+// base64 encoded basic authentication credentials for the given username and password.
+func BasicAuth(username string, password string) Attr {
+	return HeaderAttr("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(username+":"+password)))
+}
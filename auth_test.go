@@ -0,0 +1,49 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"testing"
+)
+
+func TestAuthAttrs(t *testing.T) {
+	token := "first"
+	for testNo, test := range []struct {
+		attr     Attr
+		expected []string
+	}{
+		{BearerAuth("abc123"), []string{"Bearer abc123", "Bearer abc123"}},
+		{BasicAuth("andy", "hunter2"), []string{
+			"Basic " + base64.StdEncoding.EncodeToString([]byte("andy:hunter2")),
+			"Basic YW5keTpodW50ZXIy",
+		}},
+		{HeaderAttr("Authorization", func() string { return "Bearer " + token }), []string{"Bearer first", "Bearer second"}},
+	} {
+		var headers []string
+		client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+			headers = append(headers, req.Header().Get("Authorization"))
+			return true, nil
+		}}
+
+		binding := NewBinding(mockRequestMethod[bool, bool], nil, nil, nil, nil, false, test.attr)
+		token = "first"
+		for i := range test.expected {
+			if _, err := binding.Execute(client); err != nil {
+				t.Fatalf("test no. %d could not execute Binding: %v", testNo+1, err)
+			}
+			token = "second"
+
+			if headers[i] != test.expected[i] {
+				t.Errorf("test no. %d execution no. %d expected Authorization header %q, not %q", testNo+1, i+1, test.expected[i], headers[i])
+			}
+		}
+	}
+
+	// Header Attr(s) should also be set on HTTPRequest(s)
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com", nil)
+	applyHeaderAttrs(HTTPRequest{req}, map[string]any{HeaderAttrPrefix + "X-Page": 2, "page": 3})
+	if actual := req.Header.Get("X-Page"); actual != "2" || len(req.Header) != 1 {
+		t.Errorf("expected only the X-Page header to be set to \"2\", got %v", req.Header)
+	}
+}
@@ -2,13 +2,17 @@ package api
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"github.com/andygello555/gotils/v2/numbers"
 	"github.com/andygello555/gotils/v2/slices"
 	"github.com/pkg/errors"
+	"net/http"
+	"net/url"
 	"reflect"
+	"runtime"
+	"strings"
 	"sync"
+	"time"
 )
 
 // Binding represents an action in an API that can be executed. It takes two type parameters:
@@ -28,6 +32,24 @@ type Binding[ResT any, RetT any] interface {
 	// GetRequestMethod returns the BindingRequestMethod that is called when Binding.Request is called. This is useful
 	// when you want to reuse a BindingRequestMethod for another Binding.
 	GetRequestMethod() BindingRequestMethod[ResT, RetT]
+	// RequestE constructs the Request that will be sent to the API in the same way as Request, except that it can also
+	// return an error if the Request could not be constructed. If a BindingRequestMethodE has been set using
+	// SetRequestMethodE then it will be called, otherwise RequestE will fall back to calling Request. Execute uses
+	// RequestE to construct the Request.
+	RequestE(args ...any) (request Request, err error)
+	// SetRequestMethodE sets the BindingRequestMethodE that is called when Binding.RequestE is called. This takes
+	// precedence over the BindingRequestMethod. This enables chaining when creating a Binding through NewBindingChain.
+	SetRequestMethodE(method BindingRequestMethodE[ResT, RetT]) Binding[ResT, RetT]
+	// RequestCtx constructs the Request that will be sent to the API in the same way as RequestE, except that the given
+	// context.Context is also available when constructing the Request. This allows a Request to be constructed using
+	// http.NewRequestWithContext, or to read values from the context.Context. If a BindingRequestMethodCtx has been set
+	// using SetRequestMethodCtx then it will be called, otherwise RequestCtx will fall back to calling RequestE. Execute
+	// uses RequestCtx to construct the Request, passing in the context.Context given to ExecuteCtx.
+	RequestCtx(ctx context.Context, args ...any) (request Request, err error)
+	// SetRequestMethodCtx sets the BindingRequestMethodCtx that is called when Binding.RequestCtx is called. This takes
+	// precedence over both the BindingRequestMethodE and the BindingRequestMethod. This enables chaining when creating a
+	// Binding through NewBindingChain.
+	SetRequestMethodCtx(method BindingRequestMethodCtx[ResT, RetT]) Binding[ResT, RetT]
 
 	// ResponseWrapper should create a wrapper for the given response type (ResT) and return the pointer reflect.Value to
 	// this wrapper. Client.Run will then unmarshal the response into this wrapper instance. This is useful for APIs
@@ -60,6 +82,17 @@ type Binding[ResT any, RetT any] interface {
 	// SetResponseMethod sets the BindingResponseMethod that is called when Binding.Response is called. This enables
 	// chaining when creating a Binding through NewBindingChain.
 	SetResponseMethod(method BindingResponseMethod[ResT, RetT]) Binding[ResT, RetT]
+	// ResponseE converts the response from the API from the type ResT to the type RetT in the same way as Response,
+	// except that it can also return an error if the response could not be converted. If a BindingResponseMethodE has
+	// been set using SetResponseMethodE then it will be called, otherwise ResponseE will fall back to calling Response.
+	// Execute uses ResponseE to convert the response.
+	ResponseE(response ResT, args ...any) (RetT, error)
+	// GetResponseMethodE returns the BindingResponseMethodE that is called when Binding.ResponseE is called. This is
+	// useful when you want to reuse a BindingResponseMethodE for another Binding.
+	GetResponseMethodE() BindingResponseMethodE[ResT, RetT]
+	// SetResponseMethodE sets the BindingResponseMethodE that is called when Binding.ResponseE is called. This takes
+	// precedence over the BindingResponseMethod. This enables chaining when creating a Binding through NewBindingChain.
+	SetResponseMethodE(method BindingResponseMethodE[ResT, RetT]) Binding[ResT, RetT]
 
 	// Params returns the BindingParam(s) that this Binding's Execute method takes. These BindingParam(s) are used for
 	// type-checking each argument passed to Execute. If no BindingParam(s) are returned by Params, then no
@@ -74,16 +107,175 @@ type Binding[ResT any, RetT any] interface {
 	// ArgsFromStrings parses the given list of string arguments into their required types for the Params of the
 	// Binding.
 	ArgsFromStrings(args ...string) ([]any, error)
+	// SetTypeChecker sets the TypeChecker that replaces the built-in type check of each argument within
+	// TypeCheckArgs. If the given TypeChecker is nil, then DefaultTypeChecker's checks will be used. It also returns
+	// the Binding so that this method can be chained with others when creating a new Binding through NewBindingChain.
+	SetTypeChecker(checker TypeChecker) Binding[ResT, RetT]
+	// SetParamGroups sets the BindingParamGroup(s) that constrain which combinations of Params can be provided
+	// together, such as a pair of mutually-exclusive Params. The BindingParamGroup(s) are checked within TypeCheckArgs
+	// after each argument has been type-checked. It also returns the Binding so that this method can be chained with
+	// others when creating a new Binding through NewBindingChain.
+	SetParamGroups(groups ...BindingParamGroup) Binding[ResT, RetT]
+	// SetArgExpander sets the ArgExpander that is called on the arguments given to Execute (and the other Execute
+	// methods) before they are type-checked by TypeCheckArgs. This allows a Binding to accept a more convenient shape of
+	// arguments, such as a single struct, which the ArgExpander expands into the positional arguments for each of the
+	// Params. It also returns the Binding so that this method can be chained with others when creating a new Binding
+	// through NewBindingChain.
+	SetArgExpander(expander ArgExpander) Binding[ResT, RetT]
+	// AddRequestInterceptor appends the given RequestInterceptor to the list of RequestInterceptor(s) that are called,
+	// in the order that they were added, on the Request constructed for each attempt to execute the Binding. This allows
+	// separate concerns, such as signing and logging, to be composed. It also returns the Binding so that this method can
+	// be chained with others when creating a new Binding through NewBindingChain.
+	AddRequestInterceptor(interceptor RequestInterceptor) Binding[ResT, RetT]
+	// SetPrecondition sets the Precondition that is checked after the arguments given to Execute (and the other Execute
+	// methods) have been type-checked, but before the Request is constructed. This is useful for cross-validating
+	// arguments in ways that are too complex for BindingParamGroup(s), or for guarding feature-flagged Binding(s). It
+	// also returns the Binding so that this method can be chained with others when creating a new Binding through
+	// NewBindingChain.
+	SetPrecondition(precondition Precondition) Binding[ResT, RetT]
+	// SetStrictAttrs sets whether Execute (and the other Execute methods) should return an error when an Attr still
+	// cannot be evaluated using the Client that the Binding is executed with. By default, Attr(s) that panic are left
+	// unevaluated, which can mean that a header or auth Attr is silently not applied. The error names each Attr that
+	// could not be evaluated. It also returns the Binding so that this method can be chained with others when creating
+	// a new Binding through NewBindingChain.
+	SetStrictAttrs(strict bool) Binding[ResT, RetT]
+	// AddResponseInterceptor appends the given ResponseInterceptor to the list of ResponseInterceptor(s) that are
+	// called, in the order that they were added, on the response wrapper (see ResponseWrapper) once the Binding has
+	// been executed successfully. It also returns the Binding so that this method can be chained with others when
+	// creating a new Binding through NewBindingChain.
+	AddResponseInterceptor(interceptor ResponseInterceptor) Binding[ResT, RetT]
 
 	// Execute will execute the BindingWrapper using the given Client and arguments. It returns the response converted to RetT
 	// using the Response method, as well as an error that could have occurred.
 	Execute(client Client, args ...any) (response RetT, err error)
+	// ExecuteCtx will execute the Binding in the same way as Execute, but the given context.Context will be passed to
+	// Client.Run. Execute calls ExecuteCtx with context.Background.
+	ExecuteCtx(ctx context.Context, client Client, args ...any) (response RetT, err error)
+	// ExecuteRaw will execute the Binding in the same way as ExecuteCtx, but will also return the response wrapper that
+	// the response was unmarshalled into by Client.Run (see ResponseWrapper). This allows metadata within the response
+	// wrapper, such as total counts or next page tokens, to be read after the response has been unwrapped. ExecuteCtx
+	// calls ExecuteRaw and discards the response wrapper.
+	ExecuteRaw(ctx context.Context, client Client, args ...any) (response RetT, responseWrapper reflect.Value, err error)
+	// Client returns the Client that was set for the Binding using SetClient. This is nil if no Client has been set.
+	Client() Client
+	// SetClient sets the Client that is used by Execute when it is given a nil Client. API.Execute also prefers this
+	// Client over the API's Client, which allows a single Schema to contain Binding(s) for multiple services. This
+	// returns the Binding so it can be chained.
+	SetClient(client Client) Binding[ResT, RetT]
+	// ExecuteWithResponse will execute the Binding in the same way as Execute, but will also return the ResponseMeta of
+	// the response. The ResponseMeta is fetched from the Client that executed the Binding (i.e. the Client set using
+	// SetClient if the given Client is nil) if it implements MetaRecorder, otherwise if the Client implements
+	// HeaderRecorder then only ResponseMeta.Header will be set. As the ResponseMeta is the latest one recorded for the
+	// name of the Binding, concurrent executions of Binding(s) with the same name using the same Client may return each
+	// other's ResponseMeta.
+	ExecuteWithResponse(client Client, args ...any) (response RetT, meta ResponseMeta, err error)
+	// Exists checks whether the resource of the Binding exists, by sending the Request of the Binding as a HEAD request
+	// without decoding a response body. It returns true if the response has a 2XX status code, false if the response
+	// has a 404 status code, and an error for any other status code. The Binding must have a GET Method, and must
+	// construct a HTTPRequest. The Client must return a HTTPError for non-2XX responses, as HTTPClient does.
+	Exists(client Client, args ...any) (bool, error)
+	// ExecuteAsync will execute the Binding in the same way as Execute, but within a new goroutine. The returned Future
+	// can be used to wait for the result of the execution. Any panic that occurs during the execution is returned as an
+	// error by Future.Wait.
+	ExecuteAsync(client Client, args ...any) *Future[RetT]
+	// ExecuteEach will execute the Binding using the given Client, which must implement StreamArrayClient, and stream
+	// the response as a JSON array. Each element of the array is decoded into ResT, then passed to the given onItem
+	// callback. If onItem returns an error, then streaming stops and the error is returned. This avoids holding the whole
+	// response in memory. The arguments are type-checked, and header Attr(s) are applied, in the same way as Execute.
+	// However, the response methods, RetryPolicy, and circuit breaker of the Binding are not used.
+	ExecuteEach(client Client, onItem func(item ResT) error, args ...any) error
 
 	// Paginated returns whether the Binding is paginated.
 	Paginated() bool
 	// SetPaginated sets whether the Binding is paginated. It also returns the Binding so that this method can be
 	// chained with others when creating a new Binding through NewBindingChain.
 	SetPaginated(paginated bool) Binding[ResT, RetT]
+	// PaginatorParamSet returns the PaginatorParamConfig that was set using SetPaginatorParamSet.
+	PaginatorParamSet() PaginatorParamConfig
+	// SetPaginatorParamSet sets the PaginatorParamConfig that explicitly maps the params that a Paginator paginates
+	// using onto the Params of the Binding. This overrides the detection of the "page" and "after" params by name, so
+	// that APIs with oddly-named pagination params can be paginated. It also returns the Binding so that this method
+	// can be chained with others when creating a new Binding through NewBindingChain.
+	SetPaginatorParamSet(set PaginatorParamConfig) Binding[ResT, RetT]
+
+	// SetRateLimitParser sets the RateLimitParser that is called by Execute after Client.Run. If the Client passed to
+	// Execute is a RateLimitedClient, and the RateLimitParser returns a RateLimit, then the RateLimit will be added to
+	// the Client using RateLimitedClient.AddRateLimit. This returns the Binding so it can be chained.
+	SetRateLimitParser(parser RateLimitParser) Binding[ResT, RetT]
+	// SetRetryPolicy sets the RetryPolicy that is used to retry Client.Run within Execute when it returns an error. If
+	// the given RetryPolicy is nil, then Client.Run will not be retried. This returns the Binding so it can be chained.
+	SetRetryPolicy(policy *RetryPolicy) Binding[ResT, RetT]
+	// SetCircuitBreaker sets a circuit breaker for the Binding that opens after the given number of consecutive
+	// failures of Client.Run within Execute. Whilst open, Execute will return ErrCircuitOpen without calling
+	// Client.Run. Once the given cooldown has elapsed, a single trial execution is allowed. If the trial succeeds, then
+	// the circuit breaker closes, otherwise it opens for another cooldown. The state of the circuit breaker is shared by
+	// all executions of the returned Binding. If maxFailures is 0 or less, then the circuit breaker is removed. This
+	// returns the Binding so it can be chained.
+	SetCircuitBreaker(maxFailures int, cooldown time.Duration) Binding[ResT, RetT]
+	// SetPollRedirect makes Execute poll asynchronous jobs. If the response to the Request has a 202 (Accepted) or 303
+	// (See Other) status code and a Location header, then Execute will wait for the given interval before making a GET
+	// request to that location. This is repeated until a response with a different status code is received, which is
+	// then used as the response of the Binding. An error is returned if polling would exceed the given maxWait, unless
+	// maxWait is 0 or less. The Client must implement MetaRecorder, and the Binding must construct a HTTPRequest. If
+	// interval is 0 or less, then polling is disabled. This returns the Binding so it can be chained.
+	SetPollRedirect(interval time.Duration, maxWait time.Duration) Binding[ResT, RetT]
+	// SetResponseValidator sets a validator that is called by Execute with the unwrapped response (see
+	// Binding.ResponseUnwrapped), before it is converted using Binding.Response. If the validator returns an error, then
+	// Execute will return that error. This is useful for catching error envelopes that are returned by an API with a 200
+	// status code. This returns the Binding so it can be chained.
+	SetResponseValidator(validator func(response ResT) error) Binding[ResT, RetT]
+	// SetAccept sets the Accept header of each Request constructed by the Binding to the given MIME type, using a header
+	// Attr (see HeaderAttr). A HTTPClient will decode the response using the Decoder that is registered for the
+	// Content-Type of the response (see HTTPClient.SetContentDecoder). This returns the Binding so it can be chained.
+	SetAccept(mime string) Binding[ResT, RetT]
+	// SetAcceptEncoding sets the Accept-Encoding header of each Request constructed by the Binding to the given content
+	// codings (such as "gzip"), using a header Attr (see HeaderAttr). A HTTPClient will decompress responses that have a
+	// gzip Content-Encoding, and will read responses from servers that ignore the header as is. This reduces bandwidth
+	// for large responses, such as list endpoints. This returns the Binding so it can be chained.
+	SetAcceptEncoding(encodings ...string) Binding[ResT, RetT]
+	// SetMaxResponseBytes limits the size of the response body for the Binding to the given number of bytes, using an Attr
+	// under the MaxResponseBytesAttrKey. This guards against huge or malicious responses from untrusted APIs. The limit
+	// is only enforced by Client(s) that read the Attr, such as HTTPClient, which will return ErrResponseTooLarge when
+	// the limit is exceeded. This returns the Binding so it can be chained.
+	SetMaxResponseBytes(n int64) Binding[ResT, RetT]
+	// SetFields declares the fields that the Binding needs from the response, using an Attr under the FieldsAttrKey.
+	// This reduces over-fetching from APIs that support sparse fieldsets. A Binding created using NewRESTBinding adds
+	// the fields to the FieldsQueryParam, and a Binding created using NewGraphQLBinding replaces the
+	// GraphQLFieldsPlaceholder within its query by the selection set for the fields (see GraphQLSelectionSet). Other
+	// Binding(s) can read the fields from the Attr within their request method. Nested fields are given as
+	// dot-separated paths, such as "owner.login". This returns the Binding so it can be chained.
+	SetFields(fields ...string) Binding[ResT, RetT]
+	// SetSingleFlight enables or disables the deduplication of identical concurrent executions. When enabled, if Execute
+	// is called whilst an execution with the same Binding name and arguments is in-flight, then it will wait for that
+	// execution to finish and return its result, rather than making another Request. Note that the same RetT value is
+	// returned to all callers, and that the context.Context of the first caller is used for the execution. A waiting
+	// caller stops waiting once its own context.Context is done, and if the execution fails because the context.Context
+	// of the first caller is done, then the waiting callers execute the Binding again rather than sharing that error.
+	// Executions are matched using ArgsKey, and arguments that cannot be encoded by ArgsKey are never deduplicated.
+	// Executions of Binding(s) with an unsafe Method are not deduplicated unless forced using SetForceCache. This
+	// returns the Binding so it can be chained.
+	SetSingleFlight(enabled bool) Binding[ResT, RetT]
+
+	// RequestTemplate returns the HTTP method and URL template that the Binding uses to construct its Request. This is
+	// purely metadata (useful for generating documentation) and is not used when constructing the Request. The third
+	// return value is false if no request template has been set using SetRequestTemplate.
+	RequestTemplate() (method string, urlTemplate string, ok bool)
+	// Method returns the HTTP method of the Binding. This is the method set using SetMethod, or, if no method has been
+	// set, the method of the request template (see SetRequestTemplate). An empty string is returned if neither have been
+	// set.
+	Method() string
+	// SetMethod sets the HTTP method of the Binding as metadata. This is used to decide how features behave for safe
+	// (e.g. GET) and unsafe (e.g. POST) methods. Responses for unsafe methods are not shared by SetSingleFlight unless
+	// forced using SetForceCache, and a RetryPolicy will not retry non-idempotent methods unless
+	// RetryPolicy.RetryNonIdempotent is set. Binding(s) with no method are treated as safe. This returns the Binding so
+	// it can be chained.
+	SetMethod(method string) Binding[ResT, RetT]
+	// SetForceCache forces features that cache or share responses, such as SetSingleFlight, to be used even when the
+	// Method of the Binding is not a safe method. This returns the Binding so it can be chained.
+	SetForceCache(force bool) Binding[ResT, RetT]
+	// SetRequestTemplate sets the HTTP method and URL template metadata returned by RequestTemplate. This is set
+	// automatically for Binding(s) created using NewRESTBinding. This returns the Binding so it can be chained.
+	SetRequestTemplate(method string, urlTemplate string) Binding[ResT, RetT]
 
 	// Name returns the name of the Binding. When using NewBinding, NewBindingChain, or NewWrappedBinding, this will be
 	// set to whatever is returned by the following line of code:
@@ -105,27 +297,180 @@ type Binding[ResT any, RetT any] interface {
 }
 
 type BindingRequestMethod[ResT any, RetT any] func(binding Binding[ResT, RetT], args ...any) (request Request)
+type BindingRequestMethodE[ResT any, RetT any] func(binding Binding[ResT, RetT], args ...any) (request Request, err error)
+type BindingRequestMethodCtx[ResT any, RetT any] func(ctx context.Context, binding Binding[ResT, RetT], args ...any) (request Request, err error)
 type BindingResponseWrapperMethod[ResT any, RetT any] func(binding Binding[ResT, RetT], args ...any) (responseWrapper reflect.Value, err error)
 type BindingResponseUnwrappedMethod[ResT any, RetT any] func(binding Binding[ResT, RetT], responseWrapper reflect.Value, args ...any) (response ResT, err error)
 type BindingResponseMethod[ResT any, RetT any] func(binding Binding[ResT, RetT], response ResT, args ...any) RetT
+type BindingResponseMethodE[ResT any, RetT any] func(binding Binding[ResT, RetT], response ResT, args ...any) (RetT, error)
 type BindingParamsMethod[ResT any, RetT any] func(binding Binding[ResT, RetT]) []BindingParam
 type BindingExecuteMethod[ResT any, RetT any] func(binding Binding[ResT, RetT], client Client, args ...any) (response RetT, err error)
 
+// requestURLContextKey is the context.Context key for a URL that should replace the URL of the HTTPRequest constructed
+// by Binding.RequestE within Binding.ExecuteCtx. This is used by Paginator(s) that paginate using Link headers.
+type requestURLContextKey struct{}
+
+// overrideRequestURL replaces the URL of the given Request with the URL stored under the requestURLContextKey in the
+// given context.Context. The URL is resolved relative to the Request's current URL. Requests that are not HTTPRequest
+// are returned as is.
+func overrideRequestURL(ctx context.Context, req Request) (Request, error) {
+	rawURL, ok := ctx.Value(requestURLContextKey{}).(string)
+	if !ok {
+		return req, nil
+	}
+
+	httpReq, ok := req.(HTTPRequest)
+	if !ok || httpReq.Request == nil {
+		return req, nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return req, errors.Wrapf(err, "could not parse request URL override %q", rawURL)
+	}
+	httpReq = HTTPRequest{httpReq.Request.Clone(httpReq.Request.Context())}
+	httpReq.URL = httpReq.URL.ResolveReference(u)
+	httpReq.Host = httpReq.URL.Host
+	return httpReq, nil
+}
+
+// TypeChecker checks a single argument for the given BindingParam within Binding.TypeCheckArgs. It returns the
+// argument to use in place of the given argument, which allows the argument to be converted, or an error if the
+// argument is not allowed. For variadic BindingParam(s), the TypeChecker is called for each element.
+type TypeChecker func(param BindingParam, arg any) (any, error)
+
+// ArgExpander expands the arguments given to Binding.Execute into the positional arguments for each BindingParam of
+// the Binding, before they are type-checked. It should return an error if the arguments cannot be expanded.
+type ArgExpander func(args []any) ([]any, error)
+
+// Precondition is checked with the Client and the type-checked arguments before a Binding is executed. If an error is
+// returned, then the execution is aborted before the Request is constructed.
+type Precondition func(client Client, args []any) error
+
+// RequestInterceptor is called on the Request constructed by a Binding before it is executed by a Client. The Request
+// can be modified in place, such as to sign it. If an error is returned, then the execution of the Binding is aborted.
+type RequestInterceptor func(req Request) error
+
+// ResponseInterceptor is called on the response wrapper (see Binding.ResponseWrapper) after a Binding has been executed
+// by a Client. If an error is returned, then the execution of the Binding is aborted.
+type ResponseInterceptor func(response any) error
+
+// RateLimitParser parses the RateLimit from the Request and the response wrapper (see Binding.ResponseWrapper) after
+// Client.Run has been executed. The second return value should be false if no RateLimit could be parsed.
+type RateLimitParser func(req Request, response any) (RateLimit, bool)
+
 // Attr is an attribute that can be passed to a Binding when using the NewBinding method. It should return a string key
 // and a value.
 type Attr func(client Client) (string, any)
 
+// HeaderAttrPrefix is the reserved prefix for the keys of Attr(s) that set a header on the Request constructed by a
+// Binding. The rest of the key is the name of the header. The value of the Attr can be a string, a func() string that
+// is called each time the Binding is executed, or any other value which will be formatted using fmt.Sprint. Header
+// Attr(s) are applied within Binding.Execute, after the Request has been constructed.
+const HeaderAttrPrefix = "header:"
+
+// HeaderAttr returns an Attr that sets the header of the given name to the given value on each Request constructed by
+// a Binding. See HeaderAttrPrefix for the types of values that are supported.
+func HeaderAttr(name string, value any) Attr {
+	return func(client Client) (string, any) { return HeaderAttrPrefix + name, value }
+}
+
+// AttrsFromStruct returns an Attr for each exported field of the given struct, or pointer to a struct. Each Attr is
+// keyed by the name of the field, unless the field has an "attr" tag, in which case the name within the tag is used.
+// Fields with the tag `attr:"-"` are skipped, and fields with the "omitempty" option, such as `attr:"region,omitempty"`,
+// are skipped when they are the zero value. Unexported and embedded struct fields are always skipped. AttrsFromStruct
+// will panic if the given value is not a struct or a non-nil pointer to a struct.
+func AttrsFromStruct(v any) []Attr {
+	val := reflect.ValueOf(v)
+	if val.Kind() == reflect.Pointer && !val.IsNil() {
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct {
+		panic(fmt.Errorf("AttrsFromStruct can only be given a struct or pointer to a struct, not %T", v))
+	}
+
+	attrs := make([]Attr, 0, val.NumField())
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Type().Field(i)
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+
+		key, options, _ := strings.Cut(field.Tag.Get("attr"), ",")
+		if key == "-" {
+			continue
+		}
+
+		if key == "" {
+			key = field.Name
+		}
+
+		if options == "omitempty" && val.Field(i).IsZero() {
+			continue
+		}
+
+		value := val.Field(i).Interface()
+		attrs = append(attrs, func(client Client) (string, any) { return key, value })
+	}
+	return attrs
+}
+
+// applyHeaderAttrs sets the headers of the given Request using the header Attr(s) within the given attrs.
+func applyHeaderAttrs(req Request, attrs map[string]any) {
+	for key, value := range attrs {
+		if !strings.HasPrefix(key, HeaderAttrPrefix) {
+			continue
+		}
+		name := strings.TrimPrefix(key, HeaderAttrPrefix)
+
+		var header string
+		switch value := value.(type) {
+		case string:
+			header = value
+		case func() string:
+			header = value()
+		default:
+			header = fmt.Sprint(value)
+		}
+		req.Header().Set(name, header)
+	}
+}
+
 type bindingProto[ResT any, RetT any] struct {
 	requestMethod           BindingRequestMethod[ResT, RetT]
+	requestMethodE          BindingRequestMethodE[ResT, RetT]
+	requestMethodCtx        BindingRequestMethodCtx[ResT, RetT]
 	responseWrapperMethod   BindingResponseWrapperMethod[ResT, RetT]
 	responseUnwrappedMethod BindingResponseUnwrappedMethod[ResT, RetT]
 	responseMethod          BindingResponseMethod[ResT, RetT]
+	responseMethodE         BindingResponseMethodE[ResT, RetT]
 	paramErr                error
 	checkedParams           bool
 	paramsMethod            BindingParamsMethod[ResT, RetT]
 	paginated               bool
+	paginatorParams         PaginatorParamConfig
 	name                    string
 	nameSet                 bool
+	rateLimitParser         RateLimitParser
+	typeChecker             TypeChecker
+	paramGroups             []BindingParamGroup
+	argExpander             ArgExpander
+	precondition            Precondition
+	strictAttrs             bool
+	requestInterceptors     []RequestInterceptor
+	responseInterceptors    []ResponseInterceptor
+	retryPolicy             *RetryPolicy
+	circuitBreaker          *circuitBreaker
+	pollRedirect            *pollRedirect
+	responseValidator       func(response ResT) error
+	singleFlight            *singleFlightGroup
+	client                  Client
+	requestTemplateMethod   string
+	requestTemplateURL      string
+	requestTemplateSet      bool
+	method                  string
+	forceCache              bool
 	attrs                   *sync.Map
 	attrFuncs               []Attr
 	attrFuncsMutex          *sync.RWMutex
@@ -137,11 +482,39 @@ func (b bindingProto[ResT, RetT]) GetRequestMethod() BindingRequestMethod[ResT,
 
 func (b bindingProto[ResT, RetT]) Request(args ...any) (request Request) {
 	if b.requestMethod == nil {
-		return nil
+		// If there is only a BindingRequestMethodE then we will use that, but we will ignore the error
+		if b.requestMethodE != nil {
+			request, _ = b.requestMethodE(b, args...)
+		}
+		return
 	}
 	return b.requestMethod(b, args...)
 }
 
+func (b bindingProto[ResT, RetT]) RequestE(args ...any) (request Request, err error) {
+	if b.requestMethodE == nil {
+		return b.Request(args...), nil
+	}
+	return b.requestMethodE(b, args...)
+}
+
+func (b bindingProto[ResT, RetT]) SetRequestMethodE(method BindingRequestMethodE[ResT, RetT]) Binding[ResT, RetT] {
+	b.requestMethodE = method
+	return &b
+}
+
+func (b bindingProto[ResT, RetT]) RequestCtx(ctx context.Context, args ...any) (request Request, err error) {
+	if b.requestMethodCtx == nil {
+		return b.RequestE(args...)
+	}
+	return b.requestMethodCtx(ctx, b, args...)
+}
+
+func (b bindingProto[ResT, RetT]) SetRequestMethodCtx(method BindingRequestMethodCtx[ResT, RetT]) Binding[ResT, RetT] {
+	b.requestMethodCtx = method
+	return &b
+}
+
 func (b bindingProto[ResT, RetT]) GetResponseWrapperMethod() BindingResponseWrapperMethod[ResT, RetT] {
 	return b.responseWrapperMethod
 }
@@ -183,20 +556,50 @@ func (b bindingProto[ResT, RetT]) SetResponseMethod(method BindingResponseMethod
 	return &b
 }
 
-func (b bindingProto[ResT, RetT]) Response(response ResT, args ...any) RetT {
+func (b bindingProto[ResT, RetT]) Response(response ResT, args ...any) (ret RetT) {
 	if b.responseMethod == nil {
+		// If there is only a BindingResponseMethodE then we will use that, but we will ignore the error
+		if b.responseMethodE != nil {
+			ret, _ = b.responseMethodE(b, response, args...)
+			return
+		}
 		return any(response).(RetT)
 	}
 	return b.responseMethod(b, response, args...)
 }
 
+func (b bindingProto[ResT, RetT]) ResponseE(response ResT, args ...any) (RetT, error) {
+	if b.responseMethodE == nil {
+		return b.Response(response, args...), nil
+	}
+	return b.responseMethodE(b, response, args...)
+}
+
+func (b bindingProto[ResT, RetT]) GetResponseMethodE() BindingResponseMethodE[ResT, RetT] {
+	return b.responseMethodE
+}
+
+func (b bindingProto[ResT, RetT]) SetResponseMethodE(method BindingResponseMethodE[ResT, RetT]) Binding[ResT, RetT] {
+	b.responseMethodE = method
+	return &b
+}
+
 func (b bindingProto[ResT, RetT]) GetParamsMethod() BindingParamsMethod[ResT, RetT] {
 	return b.paramsMethod
 }
 
-func checkParams(params []BindingParam) (err error) {
+// ValidateParams checks whether the given BindingParam(s) make sense. This means that:
+//   - BindingParam(s) should have a unique BindingParam.name.
+//   - Non-required BindingParam(s) should trail after all required BindingParam(s).
+//   - Variadic BindingParam(s) should trail after all non-required BindingParam(s).
+//   - Variadic BindingParam(s) should not be required.
+//   - Variadic BindingParam(s) should have a default value that is an empty reflect.Slice/reflect.Array.
+//
+// The BindingParam(s) of a Binding are validated when Binding.Params is first called, and the error is returned by
+// Binding.Execute. ValidateParams can be used to fail fast when constructing BindingParam(s) dynamically.
+func ValidateParams(params []BindingParam) (err error) {
 	namesToIdx := make(map[string]int)
-	lastRequiredParam := 0
+	firstOptionalParam := -1
 	for i, param := range params {
 		if sameNameIdx, ok := namesToIdx[param.name]; ok {
 			err = fmt.Errorf(
@@ -207,16 +610,7 @@ func checkParams(params []BindingParam) (err error) {
 		}
 		namesToIdx[param.name] = i
 
-		if param.required {
-			if lastRequiredParam <= i-2 {
-				err = fmt.Errorf(
-					"required param %q (no. %d) cannot come after series of non-required params (i.e. non-reqiured params must be placed after required params)",
-					param.name, i,
-				)
-				return
-			}
-			lastRequiredParam = i
-		} else if param.variadic {
+		if param.variadic {
 			if i != len(params)-1 {
 				err = fmt.Errorf(
 					"variadic param %q (no. %d) must be at the end of all parameters (currently lies %s to last)",
@@ -249,26 +643,32 @@ func checkParams(params []BindingParam) (err error) {
 				)
 				return
 			}
+			continue
+		}
+
+		switch {
+		case param.required && firstOptionalParam >= 0:
+			err = fmt.Errorf(
+				"required param %q (no. %d) cannot come after non-required param %q (no. %d) (i.e. non-required params must be placed after required params)",
+				param.name, i, params[firstOptionalParam].name, firstOptionalParam,
+			)
+			return
+		case !param.required && firstOptionalParam < 0:
+			firstOptionalParam = i
 		}
 	}
 	return
 }
 
-// checkParams will see if the given BindingParam(s) make sense. This means that:
-//   - BindingParam(s) should have a unique BindingParam.name.
-//   - Non Required BindingParam(s) should trail afterParamSet all Required BindingParam(s).
-//   - Variadic BindingParam(s) should trail afterParamSet all non-required BindingParam(s)
-//   - Variadic BindingParam(s) should not be Required.
-//   - Variadic BindingParam(s) should have DefaultValue that is an empty reflect.Slice/reflect.Array type.
-//
-// Check BindingParam(s) will not run again if checkedParams is set. If there is an error in the given params then we
-// will set the paramErr to an appropriate error which then can be returned in Execute.
+// checkParams will validate the given BindingParam(s) using ValidateParams. Check BindingParam(s) will not run again if
+// checkedParams is set. If there is an error in the given params then we will set the paramErr to an appropriate error
+// which then can be returned in Execute.
 func (b bindingProto[ResT, RetT]) checkParams(params []BindingParam) {
 	if !b.checkedParams {
 		defer func() {
 			b.checkedParams = true
 		}()
-		b.paramErr = checkParams(params)
+		b.paramErr = ValidateParams(params)
 	}
 	return
 }
@@ -303,20 +703,79 @@ func (b bindingProto[ResT, RetT]) ArgsFromStrings(args ...string) (parsedArgs []
 
 	parsedArgs = make([]any, 0)
 	for i, arg := range args {
-		param := params[i]
-		if param.Type().Kind() == reflect.String {
-			arg = fmt.Sprintf("%q", arg)
+		var t reflect.Type
+		switch {
+		case i < len(params) && !params[i].variadic:
+			t = params[i].Type()
+		case len(params) > 0 && params[len(params)-1].variadic:
+			// Any trailing args are parsed into the element type of the variadic param
+			t = params[len(params)-1].Type().Elem()
+		default:
+			err = fmt.Errorf("arg %q, no. %d, does not have a corresponding param", arg, i)
+			return
 		}
-		val := reflect.New(param.Type())
-		if err = json.Unmarshal([]byte(arg), val.Interface()); err != nil {
-			err = errors.Wrapf(err, "could not parse arg %q, no. %d, to type %s", arg, i, param.Type())
+
+		var parsedArg any
+		if parsedArg, err = parseArg(t, arg); err != nil {
+			err = errors.Wrapf(err, "could not parse arg %q, no. %d, to type %s", arg, i, t)
 			return
 		}
-		parsedArgs = append(parsedArgs, val.Elem().Interface())
+		parsedArgs = append(parsedArgs, parsedArg)
 	}
 	return
 }
 
+// typeCheckArg is the built-in type check for a single argument of the given BindingParam. It returns the type of the
+// argument, as well as whether that type is allowed for the BindingParam.
+func typeCheckArg(param BindingParam, arg any) (reflect.Type, bool) {
+	argType := reflect.TypeOf(arg)
+	paramType := param.Type()
+	if param.variadic {
+		paramType = paramType.Elem()
+	}
+
+	// If the param's type (or the element type for variadic params) is an interface then we check whether the
+	// argument implements that interface. Nil arguments are also allowed for interfaces.
+	if param.interfaceFlag || paramType.Kind() == reflect.Interface {
+		return argType, argType == nil || argType.Implements(paramType)
+	}
+	return argType, argType == paramType
+}
+
+// spreadVariadicArgs spreads the given arguments for a variadic BindingParam when they consist of a single argument
+// that has the same type as the BindingParam (i.e. a slice of its elements), and that argument is not itself a valid
+// element. This allows an already built slice to be passed as the argument for a variadic BindingParam. Otherwise, the
+// arguments are returned as is.
+func spreadVariadicArgs(param BindingParam, args []any) []any {
+	if len(args) != 1 || args[0] == nil || reflect.TypeOf(args[0]) != param.Type() {
+		return args
+	}
+
+	if _, pass := typeCheckArg(param, args[0]); pass {
+		return args
+	}
+
+	val := reflect.ValueOf(args[0])
+	spread := make([]any, val.Len())
+	for i := range spread {
+		spread[i] = val.Index(i).Interface()
+	}
+	return spread
+}
+
+// DefaultTypeChecker is the TypeChecker that implements the built-in type checks that are used by
+// Binding.TypeCheckArgs when no TypeChecker has been set. It can be used as a fallback by custom TypeChecker(s).
+func DefaultTypeChecker(param BindingParam, arg any) (any, error) {
+	if argType, pass := typeCheckArg(param, arg); !pass {
+		return arg, fmt.Errorf("param %q's type (%s) does not match arg's type (%s)", param.name, param.Type(), argType)
+	}
+
+	if err := param.checkMapSchema(arg); err != nil {
+		return arg, errors.Wrapf(err, "param %q's arg does not match its map schema", param.name)
+	}
+	return arg, nil
+}
+
 func (b bindingProto[ResT, RetT]) TypeCheckArgs(args ...any) (newArgs []any, err error) {
 	params := b.Params()
 	// Check if paramErr was set by checkParams
@@ -328,16 +787,6 @@ func (b bindingProto[ResT, RetT]) TypeCheckArgs(args ...any) (newArgs []any, err
 	// Then we get the type info for the params and check them against the given args.
 	newArgs = make([]any, 0)
 	if len(params) > 0 {
-		typeCheck := func(param BindingParam, arg any) (reflect.Type, bool) {
-			argType := reflect.TypeOf(arg)
-			if param.interfaceFlag {
-				return argType, argType.Implements(param.Type())
-			} else if param.variadic {
-				return argType, param.Type().Elem() == argType
-			}
-			return argType, argType == param.Type()
-		}
-
 		for i, param := range params {
 			if i < len(args) {
 				// If the parameter is variadic, then we will check if the rest of the arguments all have the same type
@@ -345,9 +794,19 @@ func (b bindingProto[ResT, RetT]) TypeCheckArgs(args ...any) (newArgs []any, err
 				// the loop.
 				if param.variadic {
 					paramElemType := param.Type().Elem()
-					for j, nextArg := range args[i:] {
-						if incorrectType, pass := typeCheck(param, nextArg); !pass {
-							err = fmt.Errorf(
+					for j, nextArg := range spreadVariadicArgs(param, args[i:]) {
+						if b.typeChecker != nil {
+							if nextArg, err = b.typeChecker(param, nextArg); err != nil {
+								err = paramErrorf(
+									param.name, i+j,
+									"variadic param %q rejected arg no. %d: %w",
+									param.name, j, err,
+								)
+								return
+							}
+						} else if incorrectType, pass := typeCheckArg(param, nextArg); !pass {
+							err = paramErrorf(
+								param.name, i+j,
 								"variadic param %q's element type (%s) does not match arg no. %d's type (%s)",
 								param.name, paramElemType, j, incorrectType,
 							)
@@ -359,61 +818,353 @@ func (b bindingProto[ResT, RetT]) TypeCheckArgs(args ...any) (newArgs []any, err
 				}
 
 				// If the parameter is non-variadic, then we will check if the argument's type matches the param's type.
-				if incorrectType, pass := typeCheck(param, args[i]); !pass {
-					err = fmt.Errorf(
+				arg := args[i]
+				if b.typeChecker != nil {
+					if arg, err = b.typeChecker(param, arg); err != nil {
+						err = paramErrorf(param.name, i, "param %q rejected arg no. %d: %w", param.name, i, err)
+						return
+					}
+				} else if incorrectType, pass := typeCheckArg(param, arg); !pass {
+					err = paramErrorf(
+						param.name, i,
 						"param %q's type (%s) does not match arg no. %d's type (%s)",
 						param.name, param.Type(), i, incorrectType,
 					)
 					return
+				} else if err = param.checkMapSchema(arg); err != nil {
+					err = paramErrorf(param.name, i, "param %q's arg no. %d does not match its map schema: %w", param.name, i, err)
+					return
 				}
-				newArgs = append(newArgs, args[i])
+				newArgs = append(newArgs, arg)
 			} else {
 				if param.required {
 					// If the parameter is required but not given, then we will return an error
-					err = fmt.Errorf("required param %q (no. %d) was not provided as an argument", param.name, i)
+					err = paramErrorf(param.name, i, "required param %q (no. %d) was not provided as an argument", param.name, i)
 					return
 				} else if !param.required && !param.variadic {
 					// If the parameter is not required and not variadic, then we will add the default value
-					newArgs = append(newArgs, param.defaultValue)
+					var def any
+					if def, err = param.defaultArg(); err != nil {
+						err = paramErrorf(param.name, i, "%w", err)
+						return
+					}
+					newArgs = append(newArgs, def)
 				}
 			}
 		}
 	}
+
+	// Finally, we check whether the combination of provided arguments satisfies each BindingParamGroup
+	for _, group := range b.paramGroups {
+		if err = group.check(params, args); err != nil {
+			return
+		}
+	}
 	return
 }
 
 func (b bindingProto[ResT, RetT]) Execute(client Client, args ...any) (response RetT, err error) {
+	return b.ExecuteCtx(context.Background(), client, args...)
+}
+
+func (b bindingProto[ResT, RetT]) ExecuteAsync(client Client, args ...any) *Future[RetT] {
+	return newFuture(func() (RetT, error) { return b.Execute(client, args...) })
+}
+
+func (b bindingProto[ResT, RetT]) ExecuteEach(client Client, onItem func(item ResT) error, args ...any) (err error) {
+	if client == nil {
+		if client = b.client; client == nil {
+			return fmt.Errorf("no Client was given to execute Binding %T, and no Client has been set using SetClient", b)
+		}
+	}
+
+	streamClient, ok := client.(StreamArrayClient)
+	if !ok {
+		return fmt.Errorf("cannot stream Binding %T as Client %T is not a StreamArrayClient", b, client)
+	}
+
+	if args, err = b.expandArgs(args); err != nil {
+		return
+	}
+
 	if args, err = b.TypeCheckArgs(args...); err != nil {
-		err = errors.Wrapf(err, "type check failed for Binding %T", b)
+		return errors.Wrapf(err, "type check failed for Binding %T", b)
+	}
+
+	if err = b.checkPrecondition(client, args); err != nil {
+		return
+	}
+
+	var attrs map[string]any
+	if attrs, err = b.executionAttrs(client); err != nil {
 		return
 	}
 
-	b.evaluateAttrs(client)
-	req := b.Request(args...)
+	var req Request
+	if req, err = b.RequestCtx(context.Background(), args...); err != nil {
+		return errors.Wrapf(err, "could not construct Request for Binding %T", b)
+	}
+	applyHeaderAttrs(req, attrs)
+
+	if err = streamClient.RunEach(withExecution(context.Background(), b.Name(), 1), b.Name(), attrs, req, func(decode func(v any) error) error {
+		var item ResT
+		if err := decode(&item); err != nil {
+			return errors.Wrapf(err, "could not decode item into %T", item)
+		}
+		return onItem(item)
+	}); err != nil {
+		err = errors.Wrapf(err, "could not stream Binding %T (%s)", b, describeRequest(req))
+	}
+	return
+}
+
+func (b bindingProto[ResT, RetT]) ExecuteWithResponse(client Client, args ...any) (response RetT, meta ResponseMeta, err error) {
+	if client == nil {
+		if client = b.client; client == nil {
+			err = fmt.Errorf("no Client was given to execute Binding %T, and no Client has been set using SetClient", b)
+			return
+		}
+	}
 
-	var responseWrapper reflect.Value
-	if responseWrapper, err = b.ResponseWrapper(args...); err != nil {
-		err = errors.Wrapf(err, "could not execute ResponseWrapper for Binding %T", b)
+	if response, err = b.Execute(client, args...); err != nil {
+		return
+	}
+	meta = latestResponseMeta(client, b.Name())
+	return
+}
+
+func (b bindingProto[ResT, RetT]) Exists(client Client, args ...any) (exists bool, err error) {
+	if client == nil {
+		if client = b.client; client == nil {
+			return false, fmt.Errorf("no Client was given to execute Binding %T, and no Client has been set using SetClient", b)
+		}
+	}
+
+	if method := b.Method(); method != "" && method != http.MethodGet {
+		return false, fmt.Errorf("cannot check whether Binding %T exists as its method is %s, not GET", b, method)
+	}
+
+	if args, err = b.expandArgs(args); err != nil {
+		return
+	}
+
+	if args, err = b.TypeCheckArgs(args...); err != nil {
+		return false, errors.Wrapf(err, "type check failed for Binding %T", b)
+	}
+
+	if err = b.checkPrecondition(client, args); err != nil {
+		return
+	}
+
+	var attrs map[string]any
+	if attrs, err = b.executionAttrs(client); err != nil {
 		return
 	}
-	responseWrapperInt := responseWrapper.Interface()
 
 	ctx := context.Background()
-	attrs := make(map[string]any)
-	b.attrs.Range(func(key, value any) bool { attrs[key.(string)] = value; return true })
-	if err = client.Run(ctx, b.Name(), attrs, req, &responseWrapperInt); err != nil {
+	var req Request
+	if req, err = b.RequestCtx(ctx, args...); err != nil {
+		return false, errors.Wrapf(err, "could not construct Request for Binding %T", b)
+	}
+
+	httpRequest, ok := req.(HTTPRequest)
+	if !ok || httpRequest.Request == nil {
+		return false, fmt.Errorf("cannot check whether Binding %T exists as its Request is a %T, not a HTTPRequest", b, req)
+	}
+
+	if httpRequest.Request.Method != "" && httpRequest.Request.Method != http.MethodGet {
+		return false, fmt.Errorf(
+			"cannot check whether Binding %T exists as its Request method is %s, not GET",
+			b, httpRequest.Request.Method,
+		)
+	}
+
+	headRequest := HTTPRequest{httpRequest.Request.Clone(ctx)}
+	headRequest.Method = http.MethodHead
+	applyHeaderAttrs(headRequest, attrs)
+
+	var response any
+	if err = client.Run(withExecution(ctx, b.Name(), 1), b.Name(), attrs, headRequest, &response); err != nil {
+		var httpErr *HTTPError
+		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "could not check whether Binding %T exists (%s)", b, describeRequest(headRequest))
+	}
+	return true, nil
+}
+
+func (b bindingProto[ResT, RetT]) ExecuteCtx(ctx context.Context, client Client, args ...any) (response RetT, err error) {
+	response, _, err = b.ExecuteRaw(ctx, client, args...)
+	return
+}
+
+// rawResult is the result of Binding.ExecuteRaw that is shared between deduplicated executions of a Binding.
+type rawResult[RetT any] struct {
+	response        RetT
+	responseWrapper reflect.Value
+}
+
+func (b bindingProto[ResT, RetT]) ExecuteRaw(ctx context.Context, client Client, args ...any) (response RetT, responseWrapper reflect.Value, err error) {
+	if err = ctx.Err(); err != nil {
+		err = errors.Wrapf(err, "context is done before executing Binding %T", b)
+		return
+	}
+
+	if client == nil {
+		if client = b.client; client == nil {
+			err = fmt.Errorf("no Client was given to execute Binding %T, and no Client has been set using SetClient", b)
+			return
+		}
+	}
+
+	// Arguments are only expanded once, as deduplicated executions are made by a copy of the Binding
+	if args, err = b.expandArgs(args); err != nil {
+		return
+	}
+	b.argExpander = nil
+
+	if b.singleFlight != nil && b.cacheable() {
+		// The execution itself must not be deduplicated again, so it is made by a copy without the singleFlightGroup
+		group := b.singleFlight
+		b.singleFlight = nil
+
+		// Arguments that cannot be canonicalised into a key are executed without deduplication
+		key, keyErr := ArgsKey(b.Name(), args)
+		if keyErr != nil {
+			return b.ExecuteRaw(ctx, client, args...)
+		}
+
+		var val any
+		if val, err, _ = group.do(ctx, key, func(ctx context.Context) (any, error) {
+			response, responseWrapper, err := b.ExecuteRaw(ctx, client, args...)
+			return rawResult[RetT]{response, responseWrapper}, err
+		}); val != nil {
+			result := val.(rawResult[RetT])
+			response, responseWrapper = result.response, result.responseWrapper
+		}
+		return
+	}
+
+	if args, err = b.TypeCheckArgs(args...); err != nil {
+		err = errors.Wrapf(err, "type check failed for Binding %T", b)
+		return
+	}
+
+	if err = b.checkPrecondition(client, args); err != nil {
+		return
+	}
+
+	var attrs map[string]any
+	if attrs, err = b.executionAttrs(client); err != nil {
+		return
+	}
+
+	var (
+		req                Request
+		responseWrapperInt any
+	)
+	if err = b.circuitBreaker.allow(); err != nil {
 		err = errors.Wrapf(err, "could not Execute Binding %T", b)
 		return
 	}
 
+	var runErr error
+	ran := false
+	defer func() { b.circuitBreaker.record(ran, runErr) }()
+	for attempt := 1; ; attempt++ {
+		// The Request and response wrapper are constructed for each attempt so that request bodies can be re-read
+		if req, err = b.RequestCtx(ctx, args...); err != nil {
+			err = errors.Wrapf(err, "could not construct Request for Binding %T", b)
+			return
+		}
+
+		if req, err = overrideRequestURL(ctx, req); err != nil {
+			return
+		}
+		applyHeaderAttrs(req, attrs)
+
+		for i, interceptor := range b.requestInterceptors {
+			if err = interceptor(req); err != nil {
+				err = errors.Wrapf(err, "request interceptor no. %d aborted execution of Binding %T", i+1, b)
+				return
+			}
+		}
+
+		if responseWrapper, err = b.ResponseWrapper(args...); err != nil {
+			err = errors.Wrapf(err, "could not execute ResponseWrapper for Binding %T", b)
+			return
+		}
+		responseWrapperInt = responseWrapper.Interface()
+
+		ran = true
+		if runErr = client.Run(withExecution(ctx, b.Name(), attempt), b.Name(), attrs, req, &responseWrapperInt); runErr == nil {
+			break
+		}
+
+		if description := describeRequest(req); description != "" {
+			err = errors.Wrapf(runErr, "could not Execute Binding %T (%s)", b, description)
+		} else {
+			err = errors.Wrapf(runErr, "could not Execute Binding %T", b)
+		}
+		if !b.retryPolicy.retryable(attempt, b.Method(), runErr) {
+			return
+		}
+
+		if waitErr := b.retryPolicy.wait(ctx, attempt, runErr); waitErr != nil {
+			err = errors.Wrapf(waitErr, "could not retry Binding %T after attempt %d failed (%v)", b, attempt, runErr)
+			return
+		}
+	}
+	err = nil
+
+	if b.pollRedirect != nil {
+		if req, responseWrapper, responseWrapperInt, err = b.poll(
+			ctx, client, attrs, req, responseWrapper, responseWrapperInt, args...,
+		); err != nil {
+			return
+		}
+	}
+
+	if rateLimitedClient, ok := client.(RateLimitedClient); ok && b.rateLimitParser != nil {
+		if rateLimit, ok := b.rateLimitParser(req, responseWrapperInt); ok {
+			rateLimitedClient.AddRateLimit(b.Name(), rateLimit)
+		}
+	}
+
+	for i, interceptor := range b.responseInterceptors {
+		if err = interceptor(responseWrapperInt); err != nil {
+			err = errors.Wrapf(err, "response interceptor no. %d aborted execution of Binding %T", i+1, b)
+			return
+		}
+	}
+
 	var responseUnwrapped ResT
 	if responseUnwrapped, err = b.ResponseUnwrapped(responseWrapper, args...); err != nil {
 		err = errors.Wrapf(err, "could not execute ResponseUnwrapped for Binding %T", b)
 		return
 	}
-	response = b.Response(responseUnwrapped, args...)
+
+	if b.responseValidator != nil {
+		if err = b.responseValidator(responseUnwrapped); err != nil {
+			err = errors.Wrapf(err, "response for Binding %T failed validation", b)
+			return
+		}
+	}
+
+	if response, err = b.ResponseE(responseUnwrapped, args...); err != nil {
+		err = errors.Wrapf(err, "could not execute Response for Binding %T", b)
+	}
 	return
 }
+
+func (b bindingProto[ResT, RetT]) Client() Client { return b.client }
+
+func (b bindingProto[ResT, RetT]) SetClient(client Client) Binding[ResT, RetT] {
+	b.client = client
+	return &b
+}
+
 func (b bindingProto[ResT, RetT]) Paginated() bool { return b.paginated }
 
 func (b bindingProto[ResT, RetT]) SetPaginated(paginated bool) Binding[ResT, RetT] {
@@ -421,6 +1172,176 @@ func (b bindingProto[ResT, RetT]) SetPaginated(paginated bool) Binding[ResT, Ret
 	return &b
 }
 
+func (b bindingProto[ResT, RetT]) PaginatorParamSet() PaginatorParamConfig { return b.paginatorParams }
+
+func (b bindingProto[ResT, RetT]) SetPaginatorParamSet(set PaginatorParamConfig) Binding[ResT, RetT] {
+	b.paginatorParams = set
+	return &b
+}
+
+func (b bindingProto[ResT, RetT]) SetTypeChecker(checker TypeChecker) Binding[ResT, RetT] {
+	b.typeChecker = checker
+	return &b
+}
+
+func (b bindingProto[ResT, RetT]) SetParamGroups(groups ...BindingParamGroup) Binding[ResT, RetT] {
+	b.paramGroups = groups
+	return &b
+}
+
+func (b bindingProto[ResT, RetT]) SetArgExpander(expander ArgExpander) Binding[ResT, RetT] {
+	b.argExpander = expander
+	return &b
+}
+
+func (b bindingProto[ResT, RetT]) AddRequestInterceptor(interceptor RequestInterceptor) Binding[ResT, RetT] {
+	// The capacity is limited so that appending never modifies the backing array of the Binding this was copied from
+	b.requestInterceptors = append(b.requestInterceptors[:len(b.requestInterceptors):len(b.requestInterceptors)], interceptor)
+	return &b
+}
+
+func (b bindingProto[ResT, RetT]) AddResponseInterceptor(interceptor ResponseInterceptor) Binding[ResT, RetT] {
+	b.responseInterceptors = append(b.responseInterceptors[:len(b.responseInterceptors):len(b.responseInterceptors)], interceptor)
+	return &b
+}
+
+func (b bindingProto[ResT, RetT]) SetStrictAttrs(strict bool) Binding[ResT, RetT] {
+	b.strictAttrs = strict
+	return &b
+}
+
+func (b bindingProto[ResT, RetT]) SetPrecondition(precondition Precondition) Binding[ResT, RetT] {
+	b.precondition = precondition
+	return &b
+}
+
+// checkPrecondition checks the Precondition of the Binding, if there is one, for the given Client and type-checked
+// arguments.
+func (b bindingProto[ResT, RetT]) checkPrecondition(client Client, args []any) error {
+	if b.precondition == nil {
+		return nil
+	}
+	return errors.Wrapf(b.precondition(client, args), "precondition failed for Binding %T", b)
+}
+
+// expandArgs expands the given arguments using the ArgExpander of the Binding, if there is one.
+func (b bindingProto[ResT, RetT]) expandArgs(args []any) ([]any, error) {
+	if b.argExpander == nil {
+		return args, nil
+	}
+
+	expanded, err := b.argExpander(args)
+	if err != nil {
+		return args, errors.Wrapf(err, "could not expand args for Binding %T", b)
+	}
+	return expanded, nil
+}
+
+func (b bindingProto[ResT, RetT]) SetRetryPolicy(policy *RetryPolicy) Binding[ResT, RetT] {
+	b.retryPolicy = policy
+	return &b
+}
+
+func (b bindingProto[ResT, RetT]) SetCircuitBreaker(maxFailures int, cooldown time.Duration) Binding[ResT, RetT] {
+	b.circuitBreaker = nil
+	if maxFailures > 0 {
+		b.circuitBreaker = newCircuitBreaker(maxFailures, cooldown)
+	}
+	return &b
+}
+
+func (b bindingProto[ResT, RetT]) SetPollRedirect(interval time.Duration, maxWait time.Duration) Binding[ResT, RetT] {
+	b.pollRedirect = nil
+	if interval > 0 {
+		b.pollRedirect = &pollRedirect{interval: interval, maxWait: maxWait}
+	}
+	return &b
+}
+
+func (b bindingProto[ResT, RetT]) SetResponseValidator(validator func(response ResT) error) Binding[ResT, RetT] {
+	b.responseValidator = validator
+	return &b
+}
+
+func (b bindingProto[ResT, RetT]) SetAccept(mime string) Binding[ResT, RetT] {
+	return b.AddAttrs(HeaderAttr("Accept", mime))
+}
+
+func (b bindingProto[ResT, RetT]) SetAcceptEncoding(encodings ...string) Binding[ResT, RetT] {
+	return b.AddAttrs(HeaderAttr("Accept-Encoding", strings.Join(encodings, ", ")))
+}
+
+func (b bindingProto[ResT, RetT]) SetMaxResponseBytes(n int64) Binding[ResT, RetT] {
+	return b.AddAttrs(func(client Client) (string, any) { return MaxResponseBytesAttrKey, n })
+}
+
+func (b bindingProto[ResT, RetT]) SetFields(fields ...string) Binding[ResT, RetT] {
+	return b.AddAttrs(func(client Client) (string, any) { return FieldsAttrKey, fields })
+}
+
+func (b bindingProto[ResT, RetT]) SetSingleFlight(enabled bool) Binding[ResT, RetT] {
+	b.singleFlight = nil
+	if enabled {
+		b.singleFlight = &singleFlightGroup{}
+	}
+	return &b
+}
+
+func (b bindingProto[ResT, RetT]) SetRateLimitParser(parser RateLimitParser) Binding[ResT, RetT] {
+	b.rateLimitParser = parser
+	return &b
+}
+
+func (b bindingProto[ResT, RetT]) RequestTemplate() (method string, urlTemplate string, ok bool) {
+	return b.requestTemplateMethod, b.requestTemplateURL, b.requestTemplateSet
+}
+
+func (b bindingProto[ResT, RetT]) SetRequestTemplate(method string, urlTemplate string) Binding[ResT, RetT] {
+	b.requestTemplateMethod = method
+	b.requestTemplateURL = urlTemplate
+	b.requestTemplateSet = true
+	return &b
+}
+
+func (b bindingProto[ResT, RetT]) Method() string {
+	if b.method == "" {
+		return strings.ToUpper(b.requestTemplateMethod)
+	}
+	return b.method
+}
+
+func (b bindingProto[ResT, RetT]) SetMethod(method string) Binding[ResT, RetT] {
+	b.method = strings.ToUpper(method)
+	return &b
+}
+
+func (b bindingProto[ResT, RetT]) SetForceCache(force bool) Binding[ResT, RetT] {
+	b.forceCache = force
+	return &b
+}
+
+// cacheable returns whether responses for the Binding can be cached or shared between executions. This is true when
+// the Method of the Binding is safe, or when SetForceCache has been used.
+func (b bindingProto[ResT, RetT]) cacheable() bool {
+	return b.forceCache || isSafeMethod(b.Method())
+}
+
+// isSafeMethod returns whether the given HTTP method is safe (i.e. read-only). An empty method is treated as safe.
+func isSafeMethod(method string) bool {
+	switch method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
+
+// isIdempotentMethod returns whether the given HTTP method is idempotent, and so can be safely retried. An empty
+// method is treated as idempotent.
+func isIdempotentMethod(method string) bool {
+	return isSafeMethod(method) || method == http.MethodPut || method == http.MethodDelete
+}
+
 func (b bindingProto[ResT, RetT]) Name() string {
 	if !b.nameSet {
 		return fmt.Sprintf("%T", b)
@@ -448,26 +1369,47 @@ func (b bindingProto[ResT, RetT]) AddAttrs(attrs ...Attr) Binding[ResT, RetT] {
 	return &b
 }
 
-func (b bindingProto[ResT, RetT]) evaluateAttrs(client Client) {
-	evaluate := func(attr Attr) (key string, val any, ok bool) {
-		defer func() {
-			if p := recover(); p != nil {
-				ok = false
-			}
-		}()
+// withOwnAttrs returns a copy of the Binding with its own copy of the Attr(s) of the Binding, to which the given
+// Attr(s) are added. Unlike AddAttrs, which shares the Attr(s) between the Binding and the copy, this does not modify
+// the Binding that it was copied from. The copy is returned as an any so that BindingWrapper can call this method without
+// knowing the type parameters of the Binding.
+func (b bindingProto[ResT, RetT]) withOwnAttrs(attrs ...Attr) any {
+	evaluated := &sync.Map{}
+	b.attrs.Range(func(key, value any) bool { evaluated.Store(key, value); return true })
+
+	b.attrFuncsMutex.RLock()
+	attrFuncs := append(append([]Attr(nil), b.attrFuncs...), attrs...)
+	b.attrFuncsMutex.RUnlock()
+
+	b.attrs, b.attrFuncs, b.attrFuncsMutex = evaluated, attrFuncs, &sync.RWMutex{}
+	return b.AddAttrs()
+}
+
+// evaluateAttrs evaluates each Attr of the Binding that has not yet been evaluated using the given Client. Attr(s) that
+// panic are left unevaluated, so they can be evaluated again once a Client is available. The returned error describes
+// each Attr that panicked.
+func (b bindingProto[ResT, RetT]) evaluateAttrs(client Client) error {
+	evaluate := func(attr Attr) (key string, val any, p any) {
+		defer func() { p = recover() }()
 		key, val = attr(client)
-		ok = true
 		return
 	}
 
 	evaluatedAttrIndexes := make([]int, 0)
+	failures := make([]string, 0)
 	b.attrFuncsMutex.RLock()
 	for i, attr := range b.attrFuncs {
-		key, val, ok := evaluate(attr)
-		if ok {
-			evaluatedAttrIndexes = append(evaluatedAttrIndexes, i)
-			b.attrs.Store(key, val)
+		key, val, p := evaluate(attr)
+		if p != nil {
+			name := "<unknown>"
+			if fn := runtime.FuncForPC(reflect.ValueOf(attr).Pointer()); fn != nil {
+				name = fn.Name()
+			}
+			failures = append(failures, fmt.Sprintf("Attr no. %d (%s) panicked: %v", i+1, name, p))
+			continue
 		}
+		evaluatedAttrIndexes = append(evaluatedAttrIndexes, i)
+		b.attrs.Store(key, val)
 	}
 	b.attrFuncsMutex.RUnlock()
 
@@ -476,6 +1418,24 @@ func (b bindingProto[ResT, RetT]) evaluateAttrs(client Client) {
 		b.attrFuncs = slices.RemoveElems(b.attrFuncs, evaluatedAttrIndexes...)
 		b.attrFuncsMutex.Unlock()
 	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("%d Attr(s) could not be evaluated: %s", len(failures), strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// executionAttrs evaluates the Attr(s) of the Binding using the given Client, and returns all the evaluated Attr(s) to
+// use when executing the Binding. If strict attrs have been enabled using SetStrictAttrs, then an error is returned if
+// any Attr could not be evaluated.
+func (b bindingProto[ResT, RetT]) executionAttrs(client Client) (attrs map[string]any, err error) {
+	if err = b.evaluateAttrs(client); err != nil && b.strictAttrs {
+		return nil, errors.Wrapf(err, "could not execute Binding %T with strict attrs", b)
+	}
+
+	attrs = make(map[string]any)
+	b.attrs.Range(func(key, value any) bool { attrs[key.(string)] = value; return true })
+	return attrs, nil
 }
 
 // NewBinding creates a new Binding for an API via a prototype that implements the Binding interface. The following
@@ -484,7 +1444,8 @@ func (b bindingProto[ResT, RetT]) evaluateAttrs(client Client) {
 // • request: the method used to construct the Request that will be sent to the API using Client.Run. This will
 // implement the Binding.Request method. The function takes the Binding, from which Binding.Attrs can be accessed, as
 // well as taking multiple arguments that should be handled accordingly. These are the same arguments passed in from the
-// Binding.Execute method. This parameter cannot be supplied a nil-pointer.
+// Binding.Execute method. This parameter cannot be supplied a nil-pointer, unless a BindingRequestMethodE is set
+// afterwards using Binding.SetRequestMethodE.
 //
 // • wrap: the method used to construct the wrapper for the response, before it is passed to Client.Run. This will
 // implement the Binding.ResponseWrapper method. The function takes the Binding, from which Binding.Attrs can be
@@ -573,3 +1534,124 @@ func NewBindingChain[ResT any, RetT any](request BindingRequestMethod[ResT, RetT
 	}
 	return b
 }
+
+// NewUnwrappingBinding creates a new Binding for an API response that returns the resource (of type Item) nested
+// within a wrapper structure (of type Wrapper). Rather than supplying wrap and unwrap methods, like NewBinding requires,
+// a fieldSelector function can be given that extracts the Item from the Wrapper. The response from Client.Run will be
+// unmarshalled into a Wrapper instance, which is then passed to the fieldSelector to implement
+// Binding.ResponseUnwrapped. The request, params, paginated, and attrs parameters are the same as those of NewBinding.
+func NewUnwrappingBinding[Wrapper any, Item any](
+	request BindingRequestMethod[Item, Item],
+	fieldSelector func(wrapper Wrapper) Item,
+	params BindingParamsMethod[Item, Item],
+	paginated bool,
+	attrs ...Attr,
+) Binding[Item, Item] {
+	return NewBinding(
+		request,
+		func(binding Binding[Item, Item], args ...any) (responseWrapper reflect.Value, err error) {
+			return reflect.ValueOf(new(Wrapper)), nil
+		},
+		func(binding Binding[Item, Item], responseWrapper reflect.Value, args ...any) (response Item, err error) {
+			wrapper, ok := responseWrapper.Interface().(*Wrapper)
+			if !ok {
+				err = fmt.Errorf("response wrapper is of type %s and not %T", responseWrapper.Type(), wrapper)
+				return
+			}
+			return fieldSelector(*wrapper), nil
+		},
+		nil, params, paginated, attrs...,
+	)
+}
+
+// SingleResult returns a BindingResponseMethod for Binding(s) that expect exactly one Item to be returned by the API
+// within a slice. The BindingResponseMethod returns the only Item in the slice, or the zero value of Item if the slice
+// is empty or contains more than one Item. Use SingleResultE to return an error in these cases instead.
+func SingleResult[Item any]() BindingResponseMethod[[]Item, Item] {
+	return func(binding Binding[[]Item, Item], response []Item, args ...any) (item Item) {
+		if len(response) == 1 {
+			item = response[0]
+		}
+		return
+	}
+}
+
+// SingleResultE returns a BindingResponseMethodE for Binding(s) that expect exactly one Item to be returned by the API
+// within a slice. The BindingResponseMethodE returns the only Item in the slice, or an error if the slice is empty or
+// contains more than one Item.
+func SingleResultE[Item any]() BindingResponseMethodE[[]Item, Item] {
+	return func(binding Binding[[]Item, Item], response []Item, args ...any) (item Item, err error) {
+		if len(response) != 1 {
+			err = fmt.Errorf("expected exactly one %T result, got %d", item, len(response))
+			return
+		}
+		return response[0], nil
+	}
+}
+
+// AsSingle derives a Binding that returns the first Item from the given Binding that returns a slice of Item(s). This
+// allows a Binding for listing resources to be reused to fetch a single resource without redefining it. The derived
+// Binding makes the same Request, is unwrapped in the same way, and takes the same Params as the given Binding. An error
+// is returned by the derived Binding's ResponseE method if the slice returned by the given Binding is empty. The
+// Client, and the other settings of the given Binding, such as its RetryPolicy and Attr(s), are also copied over to
+// the derived Binding, but the derived Binding is never paginated. If the given Binding is named, then the derived
+// Binding is named after it with a "/single" suffix, so that anything keyed by the name of a Binding (e.g. cached
+// responses) is not shared between Binding(s) with different return types.
+func AsSingle[Item any](sliceBinding Binding[[]Item, []Item]) Binding[[]Item, Item] {
+	b := NewBindingChain[[]Item, Item](nil).SetRequestMethodE(func(binding Binding[[]Item, Item], args ...any) (Request, error) {
+		return sliceBinding.RequestE(args...)
+	}).SetRequestMethodCtx(func(ctx context.Context, binding Binding[[]Item, Item], args ...any) (Request, error) {
+		return sliceBinding.RequestCtx(ctx, args...)
+	}).SetResponseWrapperMethod(func(binding Binding[[]Item, Item], args ...any) (reflect.Value, error) {
+		return sliceBinding.ResponseWrapper(args...)
+	}).SetResponseUnwrappedMethod(func(binding Binding[[]Item, Item], responseWrapper reflect.Value, args ...any) ([]Item, error) {
+		return sliceBinding.ResponseUnwrapped(responseWrapper, args...)
+	}).SetResponseMethodE(func(binding Binding[[]Item, Item], response []Item, args ...any) (item Item, err error) {
+		var items []Item
+		if items, err = sliceBinding.ResponseE(response, args...); err != nil {
+			return
+		}
+
+		if len(items) == 0 {
+			err = fmt.Errorf("expected at least one %T result, got none", item)
+			return
+		}
+		return items[0], nil
+	}).SetParamsMethod(func(binding Binding[[]Item, Item]) []BindingParam {
+		return sliceBinding.Params()
+	}).SetClient(sliceBinding.Client()).(*bindingProto[[]Item, Item])
+
+	for key, value := range sliceBinding.Attrs() {
+		b.attrs.Store(key, value)
+	}
+
+	proto, ok := sliceBinding.(*bindingProto[[]Item, []Item])
+	if !ok {
+		b.name, b.nameSet = sliceBinding.Name()+"/single", true
+		return b
+	}
+
+	if proto.nameSet {
+		b.name, b.nameSet = proto.name+"/single", true
+	}
+	b.rateLimitParser = proto.rateLimitParser
+	b.typeChecker = proto.typeChecker
+	b.paramGroups = proto.paramGroups
+	b.argExpander = proto.argExpander
+	b.precondition = proto.precondition
+	b.strictAttrs = proto.strictAttrs
+	b.requestInterceptors, b.responseInterceptors = proto.requestInterceptors, proto.responseInterceptors
+	b.retryPolicy = proto.retryPolicy
+	// The derived Binding gets its own circuit breaker, so that its failures do not open the circuit for the list Binding
+	b.circuitBreaker = proto.circuitBreaker.clone()
+	b.pollRedirect = proto.pollRedirect
+	b.responseValidator = proto.responseValidator
+	b.method, b.forceCache = proto.method, proto.forceCache
+	b.requestTemplateMethod, b.requestTemplateURL, b.requestTemplateSet = proto.RequestTemplate()
+
+	// Attr(s) that have not yet been evaluated are copied over so that they can be evaluated by the derived Binding
+	proto.attrFuncsMutex.RLock()
+	b.attrFuncs = append(b.attrFuncs, proto.attrFuncs...)
+	proto.attrFuncsMutex.RUnlock()
+	return b
+}
@@ -0,0 +1,721 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewUnwrappingBinding(t *testing.T) {
+	type item struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	type wrapper struct {
+		Data item `json:"data"`
+	}
+
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		return map[string]any{"data": map[string]any{"id": req.(*mockRequest).args[0], "name": "gapi"}}, nil
+	}}
+
+	binding := NewUnwrappingBinding(
+		mockRequestMethod[item, item],
+		func(w wrapper) item { return w.Data },
+		func(binding Binding[item, item]) []BindingParam { return Params("id", 0, true) },
+		false,
+	)
+
+	actual, err := binding.Execute(client, 42)
+	if err != nil {
+		t.Fatalf("could not execute Binding: %v", err)
+	}
+
+	if expected := (item{ID: 42, Name: "gapi"}); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, not %v", expected, actual)
+	}
+}
+
+type mockRateLimit struct {
+	reset     time.Time
+	remaining int
+	used      int
+	t         RateLimitType
+}
+
+func (rl mockRateLimit) Reset() time.Time    { return rl.reset }
+func (rl mockRateLimit) Remaining() int      { return rl.remaining }
+func (rl mockRateLimit) Used() int           { return rl.used }
+func (rl mockRateLimit) Type() RateLimitType { return rl.t }
+
+// mockRateLimitedClient is a RateLimitedClient that wraps a mockClient.
+type mockRateLimitedClient struct {
+	*mockClient
+	rateLimits sync.Map
+	logs       []string
+}
+
+func (c *mockRateLimitedClient) RateLimits() *sync.Map { return &c.rateLimits }
+
+func (c *mockRateLimitedClient) AddRateLimit(bindingName string, rateLimit RateLimit) {
+	c.rateLimits.Store(bindingName, rateLimit)
+}
+
+func (c *mockRateLimitedClient) LatestRateLimit(bindingName string) RateLimit {
+	if rateLimit, ok := c.rateLimits.Load(bindingName); ok {
+		return rateLimit.(RateLimit)
+	}
+	return nil
+}
+
+func (c *mockRateLimitedClient) Log(msg string) { c.logs = append(c.logs, msg) }
+
+func TestBindingProto_SetRateLimitParser(t *testing.T) {
+	type response struct {
+		Remaining int  `json:"remaining"`
+		OK        bool `json:"ok"`
+	}
+
+	reset := time.Now().Add(time.Minute)
+	client := &mockRateLimitedClient{mockClient: &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		return response{Remaining: 99, OK: true}, nil
+	}}}
+
+	binding := NewBindingChain(mockRequestMethod[response, response]).SetName("limited").SetRateLimitParser(func(req Request, res any) (RateLimit, bool) {
+		r, ok := res.(*response)
+		if !ok {
+			return nil, false
+		}
+		return mockRateLimit{reset: reset, remaining: r.Remaining, used: 1, t: RequestRateLimit}, true
+	})
+
+	if _, err := binding.Execute(client); err != nil {
+		t.Fatalf("could not execute Binding: %v", err)
+	}
+
+	rateLimit := client.LatestRateLimit("limited")
+	if rateLimit == nil {
+		t.Fatalf("expected a RateLimit to be added for \"limited\"")
+	}
+
+	if rateLimit.Remaining() != 99 || !rateLimit.Reset().Equal(reset) || rateLimit.Type() != RequestRateLimit {
+		t.Errorf("parsed RateLimit %+v does not match the expected RateLimit", rateLimit)
+	}
+}
+
+// mockMetaRecorderClient is a MetaRecorder that wraps a mockClient. Each response is recorded with a status code equal
+// to 200 plus the number of requests made so far.
+type mockMetaRecorderClient struct {
+	*mockClient
+	metas sync.Map
+}
+
+func (c *mockMetaRecorderClient) Run(ctx context.Context, bindingName string, attrs map[string]any, req Request, res any) error {
+	if err := c.mockClient.Run(ctx, bindingName, attrs, req, res); err != nil {
+		return err
+	}
+
+	header := make(http.Header)
+	header.Set("X-Request-No", strconv.Itoa(c.requests))
+	c.metas.Store(bindingName, ResponseMeta{StatusCode: 200 + c.requests, Header: header})
+	return nil
+}
+
+func (c *mockMetaRecorderClient) LatestMeta(bindingName string) (ResponseMeta, bool) {
+	if meta, ok := c.metas.Load(bindingName); ok {
+		return meta.(ResponseMeta), true
+	}
+	return ResponseMeta{}, false
+}
+
+func TestBindingProto_ExecuteWithResponse(t *testing.T) {
+	client := &mockMetaRecorderClient{mockClient: &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		return "hello", nil
+	}}}
+
+	binding := NewBindingChain(mockRequestMethod[string, string]).SetName("meta")
+	for testNo := 1; testNo <= 2; testNo++ {
+		response, meta, err := binding.ExecuteWithResponse(client)
+		if err != nil {
+			t.Fatalf("test no. %d could not execute Binding: %v", testNo, err)
+		}
+
+		if response != "hello" {
+			t.Errorf("test no. %d expected response %q, not %q", testNo, "hello", response)
+		}
+
+		if meta.StatusCode != 200+testNo {
+			t.Errorf("test no. %d expected status code %d, not %d", testNo, 200+testNo, meta.StatusCode)
+		}
+
+		if requestNo := meta.Header.Get("X-Request-No"); requestNo != strconv.Itoa(testNo) {
+			t.Errorf("test no. %d expected X-Request-No header to be %d, not %q", testNo, testNo, requestNo)
+		}
+	}
+
+	// The ResponseMeta should be fetched from the Client set using SetClient when no Client is given
+	if _, meta, err := binding.SetClient(client).ExecuteWithResponse(nil); err != nil {
+		t.Errorf("could not execute Binding using its own Client: %v", err)
+	} else if meta.StatusCode != 203 {
+		t.Errorf("expected status code 203 from the Binding's own Client, not %d", meta.StatusCode)
+	}
+
+	// A Client that does not implement MetaRecorder or HeaderRecorder should return an empty ResponseMeta
+	if _, meta, err := WrapBinding(binding).ExecuteWithResponse(client.mockClient); err != nil {
+		t.Errorf("could not execute BindingWrapper: %v", err)
+	} else if !reflect.DeepEqual(meta, ResponseMeta{}) {
+		t.Errorf("expected empty ResponseMeta, not %v", meta)
+	}
+}
+
+func TestBindingProto_SetTypeChecker(t *testing.T) {
+	// widenInts converts int arguments to the int64/float64 types of their BindingParam(s)
+	widenInts := func(param BindingParam, arg any) (any, error) {
+		if i, ok := arg.(int); ok {
+			paramType := param.Type()
+			if param.IsVariadic() {
+				paramType = paramType.Elem()
+			}
+
+			switch paramType.Kind() {
+			case reflect.Int64:
+				return int64(i), nil
+			case reflect.Float64:
+				return float64(i), nil
+			}
+		}
+		return DefaultTypeChecker(param, arg)
+	}
+
+	binding := NewBindingChain(mockRequestMethod[bool, bool]).SetParamsMethod(func(binding Binding[bool, bool]) []BindingParam {
+		return Params("id", int64(0), true, "scale", 1.0, "rest", []float64{}, false, true)
+	})
+
+	for testNo, test := range []struct {
+		checker      TypeChecker
+		args         []any
+		expectedArgs []any
+		expectedErr  string
+	}{
+		{
+			args:        []any{1},
+			expectedErr: "param \"id\"'s type (int64) does not match arg no. 0's type (int)",
+		},
+		{
+			checker:      widenInts,
+			args:         []any{1},
+			expectedArgs: []any{int64(1), 1.0},
+		},
+		{
+			checker:      widenInts,
+			args:         []any{int64(2), 3, 4, 5.5},
+			expectedArgs: []any{int64(2), 3.0, 4.0, 5.5},
+		},
+		{
+			checker:     widenInts,
+			args:        []any{1, "two"},
+			expectedErr: "param \"scale\" rejected arg no. 1: param \"scale\"'s type (float64) does not match arg's type (string)",
+		},
+	} {
+		args, err := binding.SetTypeChecker(test.checker).(*bindingProto[bool, bool]).TypeCheckArgs(test.args...)
+		if test.expectedErr != "" {
+			var paramErr *ParamError
+			if !errors.As(err, &paramErr) || err.Error() != test.expectedErr {
+				t.Errorf("test no. %d expected ParamError %q, not %v", testNo+1, test.expectedErr, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("test no. %d returned an unexpected error: %v", testNo+1, err)
+		} else if !reflect.DeepEqual(args, test.expectedArgs) {
+			t.Errorf("test no. %d expected args %v, not %v", testNo+1, test.expectedArgs, args)
+		}
+	}
+}
+
+func TestSingleResult(t *testing.T) {
+	type product struct {
+		ID int `json:"id"`
+	}
+
+	for testNo, test := range []struct {
+		products        []product
+		expectedProduct product
+		expectedErr     string
+	}{
+		{
+			products:    []product{},
+			expectedErr: "expected exactly one api.product result, got 0",
+		},
+		{
+			products:        []product{{ID: 1}},
+			expectedProduct: product{ID: 1},
+		},
+		{
+			products:    []product{{ID: 1}, {ID: 2}},
+			expectedErr: "expected exactly one api.product result, got 2",
+		},
+	} {
+		client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+			return test.products, nil
+		}}
+
+		// SingleResult returns the zero value when there is not exactly one product...
+		binding := NewBindingChain(mockRequestMethod[[]product, product]).SetResponseMethod(SingleResult[product]())
+		if actual, err := binding.Execute(client); err != nil {
+			t.Errorf("test no. %d SingleResult returned an unexpected error: %v", testNo+1, err)
+		} else if actual != test.expectedProduct {
+			t.Errorf("test no. %d SingleResult expected %v, not %v", testNo+1, test.expectedProduct, actual)
+		}
+
+		// ...whereas SingleResultE returns an error
+		binding = NewBindingChain(mockRequestMethod[[]product, product]).SetResponseMethodE(SingleResultE[product]())
+		actual, err := binding.Execute(client)
+		switch {
+		case test.expectedErr != "" && (err == nil || errors.Cause(err).Error() != test.expectedErr):
+			t.Errorf("test no. %d SingleResultE expected error %q, not %v", testNo+1, test.expectedErr, err)
+		case test.expectedErr == "" && err != nil:
+			t.Errorf("test no. %d SingleResultE returned an unexpected error: %v", testNo+1, err)
+		case actual != test.expectedProduct:
+			t.Errorf("test no. %d SingleResultE expected %v, not %v", testNo+1, test.expectedProduct, actual)
+		}
+	}
+}
+
+func TestAsSingle(t *testing.T) {
+	type product struct {
+		ID int `json:"id"`
+	}
+
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		limit := req.(*mockRequest).args[0].(int)
+		products := make([]product, 0, limit)
+		for i := 1; i <= limit; i++ {
+			products = append(products, product{ID: i})
+		}
+		return products, nil
+	}}
+
+	listBinding := NewBindingChain(mockRequestMethod[[]product, []product]).SetParamsMethod(func(binding Binding[[]product, []product]) []BindingParam {
+		return Params("limit", 3)
+	}).SetName("products")
+	singleBinding := AsSingle(listBinding)
+
+	if name := singleBinding.Name(); name != "products/single" {
+		t.Errorf("expected the derived Binding to be named %q, not %q", "products/single", name)
+	}
+
+	if products, err := listBinding.Execute(client); err != nil {
+		t.Errorf("list Binding returned an unexpected error: %v", err)
+	} else if expected := []product{{1}, {2}, {3}}; !reflect.DeepEqual(products, expected) {
+		t.Errorf("list Binding expected %v, not %v", expected, products)
+	}
+
+	if p, err := singleBinding.Execute(client, 2); err != nil {
+		t.Errorf("single Binding returned an unexpected error: %v", err)
+	} else if expected := (product{ID: 1}); p != expected {
+		t.Errorf("single Binding expected %v, not %v", expected, p)
+	}
+
+	expectedErr := "expected at least one api.product result, got none"
+	if _, err := singleBinding.Execute(client, 0); err == nil || errors.Cause(err).Error() != expectedErr {
+		t.Errorf("single Binding expected error %q, not %v", expectedErr, err)
+	}
+}
+
+func TestBindingProto_ExecuteErrorDescribesRequest(t *testing.T) {
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		return nil, errors.New("connection refused")
+	}}
+
+	for testNo, test := range []struct {
+		binding             BindingWrapper
+		expectedDescription string
+	}{
+		{
+			binding: WrapBinding(NewRESTBinding[bool, bool](http.MethodGet, "https://example.com/items/{id}", func(binding Binding[bool, bool]) []BindingParam {
+				return Params("id", 0, true)
+			}, false)),
+			expectedDescription: "(GET https://example.com/items/1)",
+		},
+		{
+			binding: WrapBinding(NewBindingChain(func(binding Binding[bool, bool], args ...any) Request {
+				return NewGraphQLRequest("query ListItems($id: ID!) { items(ids: [$id]) { id } }", nil)
+			})),
+			expectedDescription: "(GraphQL operation \"ListItems\")",
+		},
+	} {
+		_, err := test.binding.Execute(client, 1)
+		if err == nil {
+			t.Errorf("test no. %d expected an error", testNo+1)
+			continue
+		}
+
+		if !strings.Contains(err.Error(), test.expectedDescription) || !strings.HasSuffix(err.Error(), "connection refused") {
+			t.Errorf("test no. %d expected error to contain %q, got %q", testNo+1, test.expectedDescription, err.Error())
+		}
+	}
+}
+
+func TestBindingProto_ResponseE(t *testing.T) {
+	first := func(binding Binding[[]int, int], response []int, args ...any) (int, error) {
+		if len(response) == 0 {
+			return 0, errors.New("response is empty")
+		}
+		return response[0], nil
+	}
+
+	var response []int
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		return response, nil
+	}}
+
+	// The BindingResponseMethodE takes precedence over the BindingResponseMethod
+	binding := NewBindingChain(mockRequestMethod[[]int, int]).SetResponseMethod(func(binding Binding[[]int, int], response []int, args ...any) int {
+		return -1
+	}).SetResponseMethodE(first)
+
+	if binding.GetResponseMethodE() == nil {
+		t.Errorf("expected GetResponseMethodE to return the BindingResponseMethodE that was set")
+	}
+
+	response = []int{}
+	if _, err := binding.Execute(client); err == nil || errors.Cause(err).Error() != "response is empty" {
+		t.Errorf("expected Execute to surface the error from ResponseE, got %v", err)
+	}
+
+	response = []int{3, 2, 1}
+	if actual, err := binding.Execute(client); err != nil || actual != 3 {
+		t.Errorf("expected Execute to return (3, nil), not (%d, %v)", actual, err)
+	}
+
+	// Response falls back to the BindingResponseMethodE when no BindingResponseMethod is set, ignoring the error
+	binding = NewBindingChain(mockRequestMethod[[]int, int]).SetResponseMethodE(first)
+	if actual := binding.Response([]int{}); actual != 0 {
+		t.Errorf("expected Response to return the zero value, not %d", actual)
+	}
+}
+
+// noopClient is a Client that sets the response to a fixed value without any encoding, which is used to benchmark
+// the overhead of Binding.Execute.
+type noopClient struct{}
+
+func (noopClient) Run(ctx context.Context, bindingName string, attrs map[string]any, req Request, res any) error {
+	*(*res.(*any)).(*int) = 42
+	return nil
+}
+
+func BenchmarkBindingProto_Execute(b *testing.B) {
+	binding := NewBindingChain(mockRequestMethod[int, int])
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := binding.Execute(noopClient{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestBindingProto_SetResponseValidator(t *testing.T) {
+	type envelope struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+		Data   int    `json:"data"`
+	}
+
+	validator := func(response envelope) error {
+		if response.Status != "ok" {
+			return fmt.Errorf("API returned status %q: %s", response.Status, response.Error)
+		}
+		return nil
+	}
+
+	for testNo, test := range []struct {
+		response    envelope
+		expected    int
+		expectedErr error
+	}{
+		{response: envelope{Status: "ok", Data: 42}, expected: 42},
+		{
+			response:    envelope{Status: "error", Error: "quota exceeded"},
+			expectedErr: fmt.Errorf("API returned status \"error\": quota exceeded"),
+		},
+	} {
+		client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+			return test.response, nil
+		}}
+
+		binding := NewBindingChain(mockRequestMethod[envelope, int]).SetResponseMethod(func(binding Binding[envelope, int], response envelope, args ...any) int {
+			return response.Data
+		}).SetResponseValidator(validator)
+
+		actual, err := binding.Execute(client)
+		switch {
+		case test.expectedErr == nil && err != nil:
+			t.Errorf("test no. %d returned an unexpected error: %v", testNo+1, err)
+		case test.expectedErr != nil && (err == nil || errors.Cause(err).Error() != test.expectedErr.Error()):
+			t.Errorf("test no. %d expected error %v, not %v", testNo+1, test.expectedErr, err)
+		case actual != test.expected:
+			t.Errorf("test no. %d expected %d, not %d", testNo+1, test.expected, actual)
+		}
+	}
+
+	// The validator should also be called when the default response methods are used
+	binding := NewBindingChain(mockRequestMethod[int, int]).SetResponseValidator(func(response int) error {
+		return fmt.Errorf("rejected %d", response)
+	})
+	if _, err := binding.Execute(noopClient{}); err == nil || errors.Cause(err).Error() != "rejected 42" {
+		t.Errorf("expected the validator to reject the response, got %v", err)
+	}
+}
+
+func TestBindingProto_SetRequestMethodCtx(t *testing.T) {
+	type traceKey struct{}
+	binding := NewBindingChain[string, string](nil).SetRequestMethodCtx(func(ctx context.Context, binding Binding[string, string], args ...any) (Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://example.com", nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("X-Trace-Id", ctx.Value(traceKey{}).(string))
+		return HTTPRequest{req}, nil
+	})
+
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		httpReq := req.(HTTPRequest).Request
+		if value, _ := httpReq.Context().Value(traceKey{}).(string); value != "trace" {
+			return nil, fmt.Errorf("expected the Request's context to carry the trace value, not %q", value)
+		}
+		return httpReq.Header.Get("X-Trace-Id"), nil
+	}}
+
+	ctx := context.WithValue(context.Background(), traceKey{}, "trace")
+	if actual, err := binding.ExecuteCtx(ctx, client); err != nil {
+		t.Errorf("could not execute Binding: %v", err)
+	} else if actual != "trace" {
+		t.Errorf("expected the trace header to be %q, not %q", "trace", actual)
+	}
+}
+
+func TestAttrsFromStruct(t *testing.T) {
+	type metadata struct {
+		Tenant   string
+		Region   string   `attr:"region,omitempty"`
+		Zone     string   `attr:"zone,omitempty"`
+		Flags    []string `attr:"featureFlags"`
+		Internal bool     `attr:"-"`
+		secret   string
+	}
+
+	binding := NewBindingChain(mockRequestMethod[string, string]).AddAttrs(AttrsFromStruct(&metadata{
+		Tenant:   "acme",
+		Zone:     "eu-west-1a",
+		Flags:    []string{"beta"},
+		Internal: true,
+		secret:   "hunter2",
+	})...)
+
+	expected := map[string]any{
+		"Tenant":       "acme",
+		"zone":         "eu-west-1a",
+		"featureFlags": []string{"beta"},
+	}
+	if actual := binding.Attrs(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected Attrs %v, not %v", expected, actual)
+	}
+}
+
+func TestBindingProto_SetArgExpander(t *testing.T) {
+	type searchInput struct {
+		Query string
+		Page  int
+		Tags  []string
+	}
+
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		return fmt.Sprintln(req.(*mockRequest).args...), nil
+	}}
+
+	binding := NewBindingChain(mockRequestMethod[string, string]).SetParamsMethod(func(binding Binding[string, string]) []BindingParam {
+		return Params("query", "", true, "page", 1, "tags", []string{})
+	}).SetArgExpander(func(args []any) ([]any, error) {
+		if len(args) != 1 {
+			return args, nil
+		}
+
+		input, ok := args[0].(searchInput)
+		if !ok {
+			return nil, fmt.Errorf("expected a searchInput, not %T", args[0])
+		}
+		return []any{input.Query, input.Page, input.Tags}, nil
+	})
+
+	response, err := binding.Execute(client, searchInput{Query: "gapi", Page: 2, Tags: []string{"go"}})
+	if err != nil {
+		t.Fatalf("could not execute Binding with expanded args: %v", err)
+	}
+
+	if expected := "gapi 2 [go]\n"; response != expected {
+		t.Errorf("expected response %q, not %q", expected, response)
+	}
+
+	if _, err = binding.Execute(client, 42); err == nil {
+		t.Errorf("expected an error when the ArgExpander cannot expand the args")
+	}
+}
+
+func TestBindingProto_AddRequestInterceptor(t *testing.T) {
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		return fmt.Sprint(req.(*mockRequest).args...), nil
+	}}
+
+	var order []string
+	binding := NewBindingChain(mockRequestMethod[string, string]).AddRequestInterceptor(func(req Request) error {
+		order = append(order, "sign")
+		req.(*mockRequest).args = append(req.(*mockRequest).args, "signed")
+		return nil
+	}).AddRequestInterceptor(func(req Request) error {
+		order = append(order, "log")
+		return nil
+	}).AddResponseInterceptor(func(response any) error {
+		order = append(order, fmt.Sprintf("response %v", *response.(*string)))
+		return nil
+	})
+
+	if _, err := binding.Execute(client); err != nil {
+		t.Fatalf("could not execute Binding: %v", err)
+	}
+
+	if expected := []string{"sign", "log", "response signed"}; !reflect.DeepEqual(order, expected) {
+		t.Errorf("expected interceptors to be called in the order %v, not %v", expected, order)
+	}
+
+	// An error from an interceptor aborts the execution before the Client is run
+	requests := client.requests
+	if _, err := binding.AddRequestInterceptor(func(req Request) error {
+		return errors.New("unauthorised")
+	}).Execute(client); err == nil || !strings.Contains(err.Error(), "request interceptor no. 3") {
+		t.Errorf("expected an error from the third request interceptor, got %v", err)
+	}
+
+	if client.requests != requests {
+		t.Errorf("expected the Client not to be run after an interceptor error")
+	}
+}
+
+func TestBindingProto_SetPrecondition(t *testing.T) {
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		return fmt.Sprintln(req.(*mockRequest).args...), nil
+	}}
+
+	built := 0
+	binding := NewBindingChain(func(binding Binding[string, string], args ...any) Request {
+		built++
+		return mockRequestMethod(binding, args...)
+	}).SetParamsMethod(func(binding Binding[string, string]) []BindingParam {
+		return Params("from", 0, "to", 0)
+	}).SetPrecondition(func(client Client, args []any) error {
+		if from, to := args[0].(int), args[1].(int); from > to {
+			return fmt.Errorf("from (%d) cannot be after to (%d)", from, to)
+		}
+		return nil
+	})
+
+	if _, err := binding.Execute(client, 1, 5); err != nil {
+		t.Errorf("expected valid range to pass the precondition: %v", err)
+	}
+
+	if _, err := binding.Execute(client, 5, 1); err == nil || !strings.Contains(err.Error(), "from (5) cannot be after to (1)") {
+		t.Errorf("expected the precondition to reject an invalid range, got %v", err)
+	}
+
+	if built != 1 || client.requests != 1 {
+		t.Errorf("expected the Request to only be built and run once, not %d and %d times", built, client.requests)
+	}
+}
+
+func TestValidateParams(t *testing.T) {
+	for testNo, test := range []struct {
+		params      []BindingParam
+		expectedErr string
+	}{
+		{params: Params("id", 0, true, "name", "", "tags", []string{}, false, true)},
+		{params: []BindingParam{ReqParam("id", 0), Param("name", ""), VarParam("tags", []string{})}},
+		{
+			params:      Params("name", "", false, "id", 0, true),
+			expectedErr: `required param "id" (no. 1) cannot come after non-required param "name" (no. 0)`,
+		},
+		{
+			params:      []BindingParam{ReqParam("id", 0), VarParam("tags", []string{}), Param("name", "")},
+			expectedErr: `variadic param "tags" (no. 1) must be at the end of all parameters`,
+		},
+		{
+			params:      Params("id", 0, true, "id", 0),
+			expectedErr: `param "id" (no. 1) has the same name as a previous param`,
+		},
+	} {
+		err := ValidateParams(test.params)
+		switch {
+		case test.expectedErr == "" && err != nil:
+			t.Errorf("test no. %d returned an unexpected error: %v", testNo+1, err)
+		case test.expectedErr != "" && (err == nil || !strings.Contains(err.Error(), test.expectedErr)):
+			t.Errorf("test no. %d expected an error containing %q, not %v", testNo+1, test.expectedErr, err)
+		}
+	}
+}
+
+func TestBindingProto_SetStrictAttrs(t *testing.T) {
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		return "ok", nil
+	}}
+
+	authAttr := func(client Client) (string, any) {
+		// The token can only be fetched from a headerRecordingClient, so this always panics for a mockClient
+		return HeaderAttrPrefix + "Authorization", client.(headerRecordingClient).LatestHeader("auth").Get("Token")
+	}
+
+	binding := NewBindingChain(mockRequestMethod[string, string]).AddAttrs(authAttr)
+	if _, err := binding.Execute(client); err != nil {
+		t.Errorf("expected an unevaluated Attr to be ignored when not strict, got %v", err)
+	}
+
+	_, err := binding.SetStrictAttrs(true).Execute(client)
+	if err == nil || !strings.Contains(err.Error(), "Attr no. 1 (github.com/andygello555/gapi.TestBindingProto_SetStrictAttrs.func2) panicked") {
+		t.Errorf("expected an error naming the Attr that could not be evaluated, got %v", err)
+	}
+
+	if client.requests != 1 {
+		t.Errorf("expected the strict execution to not run the Client, but %d requests were made", client.requests)
+	}
+}
+
+func TestAsSingle_CircuitBreaker(t *testing.T) {
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		return []int{1, 2, 3}, nil
+	}}
+	failingClient := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		return nil, errors.New("server error")
+	}}
+
+	listBinding := NewBindingChain(mockRequestMethod[[]int, []int]).SetCircuitBreaker(1, time.Hour)
+	singleBinding := AsSingle(listBinding)
+
+	if _, err := singleBinding.Execute(failingClient); err == nil {
+		t.Fatalf("expected the single Binding to fail")
+	}
+
+	if _, err := singleBinding.Execute(client); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected the circuit of the single Binding to be open, not %v", err)
+	}
+
+	if _, err := listBinding.Execute(client); err != nil {
+		t.Errorf("expected the circuit of the list Binding to still be closed, not %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// CachedResponse is a response that is stored within a ResponseCache, so that a conditional request can be made using
+// its ETag. When the server responds with 304 (Not Modified), the Body of the CachedResponse is used instead.
+type CachedResponse struct {
+	ETag   string      `json:"etag"`
+	Header http.Header `json:"header,omitempty"`
+	Body   []byte      `json:"body"`
+}
+
+// ResponseCache is a backend that stores CachedResponse(s) for a HTTPClient (see HTTPClient.SetResponseCache). A
+// ResponseCache can be backed by anything, such as the filesystem (see FileResponseCache) or Redis, so that conditional
+// requests can be made across process restarts. Implementations must be safe for concurrent use.
+type ResponseCache interface {
+	// Get returns the CachedResponse for the given key. The second return value is false if there is no
+	// CachedResponse for the key.
+	Get(key string) (CachedResponse, bool, error)
+	// Set stores the CachedResponse under the given key, replacing any existing CachedResponse.
+	Set(key string, response CachedResponse) error
+}
+
+// FileResponseCache is a ResponseCache that stores each CachedResponse as a JSON file within a directory. The name of
+// each file is the SHA-256 hash of its key.
+type FileResponseCache struct {
+	dir string
+}
+
+// NewFileResponseCache creates a new FileResponseCache that stores its files within the given directory. The directory
+// is created when the first CachedResponse is stored.
+func NewFileResponseCache(dir string) *FileResponseCache {
+	return &FileResponseCache{dir: dir}
+}
+
+// path returns the path to the file for the given key.
+func (c *FileResponseCache) path(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(hash[:])+".json")
+}
+
+// Get reads the CachedResponse for the given key from its file.
+func (c *FileResponseCache) Get(key string) (response CachedResponse, ok bool, err error) {
+	var data []byte
+	if data, err = os.ReadFile(c.path(key)); err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return response, false, errors.Wrapf(err, "could not read cached response for %q", key)
+	}
+
+	if err = json.Unmarshal(data, &response); err != nil {
+		return response, false, errors.Wrapf(err, "could not unmarshal cached response for %q", key)
+	}
+	return response, true, nil
+}
+
+// Set writes the CachedResponse for the given key to its file. The file is written to a temporary file first and then
+// renamed, so that a concurrent Get never reads a partially written file.
+func (c *FileResponseCache) Set(key string, response CachedResponse) (err error) {
+	var data []byte
+	if data, err = json.Marshal(response); err != nil {
+		return errors.Wrapf(err, "could not marshal cached response for %q", key)
+	}
+
+	if err = os.MkdirAll(c.dir, 0755); err != nil {
+		return errors.Wrapf(err, "could not create response cache directory %q", c.dir)
+	}
+
+	var file *os.File
+	if file, err = os.CreateTemp(c.dir, "*.tmp"); err != nil {
+		return errors.Wrapf(err, "could not create temporary file for cached response for %q", key)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err = file.Write(data); err != nil {
+		_ = file.Close()
+		return errors.Wrapf(err, "could not write cached response for %q", key)
+	}
+
+	if err = file.Close(); err != nil {
+		return errors.Wrapf(err, "could not write cached response for %q", key)
+	}
+	return errors.Wrapf(os.Rename(file.Name(), c.path(key)), "could not store cached response for %q", key)
+}
+
+// responseCacheKey returns the key of the given http.Request within a ResponseCache.
+func responseCacheKey(req *http.Request) string {
+	return req.Method + " " + req.URL.String()
+}
+
+// cachingBody wraps the body of a response that has an ETag, and stores the body within a ResponseCache once it has
+// been read in full.
+type cachingBody struct {
+	io.ReadCloser
+	cache    ResponseCache
+	key      string
+	response CachedResponse
+	buf      bytes.Buffer
+}
+
+func (b *cachingBody) Read(p []byte) (n int, err error) {
+	n, err = b.ReadCloser.Read(p)
+	b.buf.Write(p[:n])
+	if err == io.EOF && b.cache != nil {
+		b.response.Body = b.buf.Bytes()
+		if cacheErr := b.cache.Set(b.key, b.response); cacheErr != nil {
+			err = cacheErr
+		}
+		b.cache = nil
+	}
+	return
+}
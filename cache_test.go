@@ -0,0 +1,60 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+func TestFileResponseCache(t *testing.T) {
+	var requests, notModified atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": 1, "name": "andy"}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	binding := NewRESTBinding[map[string]any, map[string]any](http.MethodGet, server.URL+"/users/1", nil, false).SetName("user")
+	expected := map[string]any{"id": float64(1), "name": "andy"}
+
+	// Each client uses a different FileResponseCache instance for the same directory to simulate a process restart
+	for testNo, expectedStatus := range []int{http.StatusOK, http.StatusNotModified} {
+		client := NewHTTPClient().SetResponseCache(NewFileResponseCache(dir))
+		actual, err := binding.Execute(client)
+		if err != nil {
+			t.Fatalf("test no. %d returned an unexpected error: %v", testNo+1, err)
+		}
+
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("test no. %d expected %v, not %v", testNo+1, expected, actual)
+		}
+
+		if meta, _ := client.LatestMeta("user"); meta.StatusCode != expectedStatus {
+			t.Errorf("test no. %d expected status code %d, not %d", testNo+1, expectedStatus, meta.StatusCode)
+		}
+	}
+
+	if requests.Load() != 2 || notModified.Load() != 1 {
+		t.Errorf("expected 2 requests with 1 not modified, not %d with %d not modified", requests.Load(), notModified.Load())
+	}
+
+	cached, ok, err := NewFileResponseCache(dir).Get(http.MethodGet + " " + server.URL + "/users/1")
+	switch {
+	case err != nil:
+		t.Errorf("could not get cached response: %v", err)
+	case !ok:
+		t.Errorf("expected a cached response")
+	case cached.ETag != `"v1"`:
+		t.Errorf("expected cached response to have ETag %q, not %q", `"v1"`, cached.ETag)
+	}
+}
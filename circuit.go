@@ -0,0 +1,96 @@
+package api
+
+import (
+	"github.com/pkg/errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by Binding.Execute when the circuit breaker for the Binding is open. See
+// Binding.SetCircuitBreaker.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker is the circuit breaker that is set using Binding.SetCircuitBreaker. It is shared between all copies
+// of the Binding that it was set for, and so it is safe for concurrent use.
+type circuitBreaker struct {
+	maxFailures int
+	cooldown    time.Duration
+	mutex       sync.Mutex
+	state       circuitState
+	failures    int
+	openedAt    time.Time
+	trialActive bool
+}
+
+func newCircuitBreaker(maxFailures int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{maxFailures: maxFailures, cooldown: cooldown}
+}
+
+// clone returns a new circuitBreaker with the same maxFailures and cooldown as the circuitBreaker, but with its own
+// closed state. A nil circuitBreaker is cloned as nil.
+func (cb *circuitBreaker) clone() *circuitBreaker {
+	if cb == nil {
+		return nil
+	}
+	return newCircuitBreaker(cb.maxFailures, cb.cooldown)
+}
+
+// allow returns ErrCircuitOpen if an execution is not allowed. Once the cooldown has elapsed for an open circuit
+// breaker, a single trial execution will be allowed. A nil circuitBreaker allows all executions.
+func (cb *circuitBreaker) allow() error {
+	if cb == nil {
+		return nil
+	}
+
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	switch cb.state {
+	case circuitOpen:
+		if time.Since(cb.openedAt) < cb.cooldown {
+			return ErrCircuitOpen
+		}
+		cb.state = circuitHalfOpen
+		cb.trialActive = true
+	case circuitHalfOpen:
+		if cb.trialActive {
+			return ErrCircuitOpen
+		}
+		cb.trialActive = true
+	}
+	return nil
+}
+
+// record records the outcome of an execution that was allowed by allow. If ran is false, then Client.Run was never
+// called, so the outcome does not affect the state of the circuitBreaker. Otherwise, the given error from Client.Run
+// will either reset the circuitBreaker, or count as a consecutive failure.
+func (cb *circuitBreaker) record(ran bool, err error) {
+	if cb == nil {
+		return
+	}
+
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	trial := cb.state == circuitHalfOpen && cb.trialActive
+	cb.trialActive = false
+	switch {
+	case !ran:
+		return
+	case err == nil:
+		cb.state = circuitClosed
+		cb.failures = 0
+	default:
+		cb.failures++
+		if trial || cb.failures >= cb.maxFailures {
+			cb.state = circuitOpen
+			cb.openedAt = time.Now()
+		}
+	}
+}
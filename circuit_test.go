@@ -0,0 +1,59 @@
+package api
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"testing"
+	"time"
+)
+
+func TestBindingProto_SetCircuitBreaker(t *testing.T) {
+	const cooldown = 50 * time.Millisecond
+	failing := true
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		if failing {
+			return nil, errors.New("upstream unavailable")
+		}
+		return true, nil
+	}}
+
+	binding := NewBindingChain(mockRequestMethod[bool, bool]).SetCircuitBreaker(2, cooldown)
+
+	// The first two failures are returned from Client.Run, after which the circuit breaker opens
+	for attempt := 1; attempt <= 2; attempt++ {
+		if _, err := binding.Execute(client); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("attempt %d expected an error from Client.Run, got %v", attempt, err)
+		}
+	}
+
+	if _, err := binding.Execute(client); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen once the circuit breaker has opened, got %v", err)
+	}
+
+	if client.requests != 2 {
+		t.Errorf("expected Client.Run to not be called whilst the circuit breaker is open, got %d requests", client.requests)
+	}
+
+	// A failing trial after the cooldown re-opens the circuit breaker straight away
+	time.Sleep(cooldown)
+	if _, err := binding.Execute(client); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected the trial execution to call Client.Run, got %v", err)
+	}
+
+	if _, err := binding.Execute(client); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen after a failed trial, got %v", err)
+	}
+
+	// A successful trial after the cooldown closes the circuit breaker
+	time.Sleep(cooldown)
+	failing = false
+	for attempt := 1; attempt <= 3; attempt++ {
+		if _, err := binding.Execute(client); err != nil {
+			t.Errorf("attempt %d expected no error once the circuit breaker has closed, got %v", attempt, err)
+		}
+	}
+
+	if client.requests != 6 {
+		t.Errorf("expected 6 requests in total, not %d", client.requests)
+	}
+}
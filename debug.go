@@ -0,0 +1,143 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// secretHeaders are the canonical names of the headers whose values are redacted by dumpHeader.
+var secretHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+}
+
+// dumpHeader writes the given http.Header to the given strings.Builder, with one header per line sorted by name. The
+// values of secretHeaders are redacted.
+func dumpHeader(b *strings.Builder, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, value := range header[name] {
+			if secretHeaders[http.CanonicalHeaderKey(name)] {
+				fmt.Fprintf(b, "%s: %v\n", name, redacted{})
+				continue
+			}
+			fmt.Fprintf(b, "%s: %s\n", name, value)
+		}
+	}
+}
+
+// DumpRequest renders the given Request as a readable string for debugging, such as comparing the Request constructed
+// by a Binding against a known-good request made using curl. A HTTPRequest is rendered as its method and URL, followed
+// by its headers and its body. The values of the Authorization, Proxy-Authorization, and Cookie headers are redacted so
+// that dumps can be shared safely. The body of the HTTPRequest is replaced so that it can still be read by a Client. A
+// RecordableGraphQLRequest is rendered as its headers, followed by its query and its variables as indented JSON.
+func DumpRequest(req Request) (string, error) {
+	var b strings.Builder
+	switch req := req.(type) {
+	case HTTPRequest:
+		if req.Request == nil || req.URL == nil {
+			return "", fmt.Errorf("cannot dump HTTPRequest with no URL")
+		}
+
+		method := req.Method
+		if method == "" {
+			method = http.MethodGet
+		}
+		fmt.Fprintf(&b, "%s %s\n", method, req.URL.String())
+		dumpHeader(&b, req.Request.Header)
+
+		if req.Body != nil && req.Body != http.NoBody {
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return "", errors.Wrapf(err, "could not read body of %s", describeRequest(req))
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+			fmt.Fprintf(&b, "\n%s\n", body)
+		}
+	case RecordableGraphQLRequest:
+		if req.Request == nil {
+			return "", fmt.Errorf("cannot dump nil RecordableGraphQLRequest")
+		}
+
+		dumpHeader(&b, req.Request.Header)
+		fmt.Fprintf(&b, "\n%s\n", strings.TrimSpace(req.Query()))
+		if vars := req.Vars(); len(vars) > 0 {
+			data, err := json.MarshalIndent(vars, "", "  ")
+			if err != nil {
+				return "", errors.Wrap(err, "could not marshal variables of RecordableGraphQLRequest")
+			}
+			fmt.Fprintf(&b, "\n%s\n", data)
+		}
+	case GraphQLRequest:
+		return "", fmt.Errorf("cannot dump GraphQLRequest as its query is unknown, create it using NewGraphQLRequest instead")
+	default:
+		return "", fmt.Errorf("cannot dump Request of type %T", req)
+	}
+	return b.String(), nil
+}
+
+// DiffRequests returns a line-by-line diff of the dumps of the given Request(s) (see DumpRequest). Lines that are only
+// within the dump of a are prefixed with "- ", lines that are only within the dump of b are prefixed with "+ ", and
+// lines that are within both are prefixed with two spaces. An empty string is returned if the dumps are equal. If a
+// Request cannot be dumped, then the error is used in place of its dump.
+func DiffRequests(a, b Request) string {
+	dump := func(req Request) []string {
+		dumped, err := DumpRequest(req)
+		if err != nil {
+			dumped = fmt.Sprintf("could not dump request: %v", err)
+		}
+		return strings.Split(strings.TrimSuffix(dumped, "\n"), "\n")
+	}
+
+	aLines, bLines := dump(a), dump(b)
+	if strings.Join(aLines, "\n") == strings.Join(bLines, "\n") {
+		return ""
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of aLines[i:] and bLines[j:]
+	lcs := make([][]int, len(aLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(bLines)+1)
+	}
+	for i := len(aLines) - 1; i >= 0; i-- {
+		for j := len(bLines) - 1; j >= 0; j-- {
+			if aLines[i] == bLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var diff strings.Builder
+	i, j := 0, 0
+	for i < len(aLines) || j < len(bLines) {
+		switch {
+		case i < len(aLines) && j < len(bLines) && aLines[i] == bLines[j]:
+			fmt.Fprintf(&diff, "  %s\n", aLines[i])
+			i++
+			j++
+		case j == len(bLines) || (i < len(aLines) && lcs[i+1][j] >= lcs[i][j+1]):
+			fmt.Fprintf(&diff, "- %s\n", aLines[i])
+			i++
+		default:
+			fmt.Fprintf(&diff, "+ %s\n", bLines[j])
+			j++
+		}
+	}
+	return diff.String()
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDumpRequest(t *testing.T) {
+	newRequest := func(body string) HTTPRequest {
+		request, err := http.NewRequest(http.MethodPost, "https://example.com/users?page=2", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("could not create request: %v", err)
+		}
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("Authorization", "Bearer token")
+		request.Header.Set("Cookie", "session=secret")
+		request.Header["proxy-authorization"] = []string{"Basic secret"}
+		return HTTPRequest{request}
+	}
+
+	req := newRequest(`{"name":"andy"}`)
+	dump, err := DumpRequest(req)
+	if err != nil {
+		t.Fatalf("could not dump request: %v", err)
+	}
+
+	for _, expected := range []string{
+		"POST https://example.com/users?page=2\n",
+		"Authorization: ****\n",
+		"Cookie: ****\n",
+		"proxy-authorization: ****\n",
+		"Content-Type: application/json\n",
+		`{"name":"andy"}`,
+	} {
+		if !strings.Contains(dump, expected) {
+			t.Errorf("expected dump to contain %q:\n%s", expected, dump)
+		}
+	}
+
+	for _, secret := range []string{"Bearer token", "session=secret", "Basic secret"} {
+		if strings.Contains(dump, secret) {
+			t.Errorf("expected dump to not contain the secret %q:\n%s", secret, dump)
+		}
+	}
+
+	// The body can still be read after dumping
+	if body, _ := io.ReadAll(req.Body); string(body) != `{"name":"andy"}` {
+		t.Errorf("expected body to still be readable after dumping, got %q", body)
+	}
+
+	if diff := DiffRequests(newRequest(`{"name":"andy"}`), newRequest(`{"name":"andy"}`)); diff != "" {
+		t.Errorf("expected no diff between equal requests, got:\n%s", diff)
+	}
+
+	diff := DiffRequests(newRequest(`{"name":"andy"}`), newRequest(`{"name":"gello"}`))
+	if !strings.Contains(diff, `- {"name":"andy"}`) || !strings.Contains(diff, `+ {"name":"gello"}`) {
+		t.Errorf("expected diff to contain the differing bodies, got:\n%s", diff)
+	}
+
+	if !strings.Contains(diff, "  POST https://example.com/users?page=2") {
+		t.Errorf("expected diff to contain the common request line, got:\n%s", diff)
+	}
+}
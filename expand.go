@@ -0,0 +1,87 @@
+package api
+
+import (
+	"github.com/pkg/errors"
+	"sync"
+)
+
+// ExpandEach returns a function that implements the "list then get" workflow, where a list endpoint returns shallow
+// items, and the full details of each item must be fetched from another endpoint. The returned function executes the
+// list Binding with the given Client and args, paginating it using a Paginator (with no wait time) if the list Binding
+// is Binding.Paginated. The detail Binding is then executed for each listed item, using the args returned by key for
+// that item. At most concurrency detail Binding(s) are executed at once (if concurrency is less than 1, then they are
+// executed one at a time). The details are returned in the same order as the listed items.
+//
+// If the list Binding, or any of the detail Binding(s), return an error, then no more detail Binding(s) are executed
+// and the first error is returned. The Client that is given to the returned function must be safe to use from
+// multiple goroutines if concurrency is greater than 1.
+func ExpandEach[ListResT any, Item any, DetailResT any, Detail any](
+	listBinding Binding[ListResT, []Item],
+	detailBinding Binding[DetailResT, Detail],
+	key func(item Item) []any,
+	concurrency int,
+) func(client Client, args ...any) ([]Detail, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	return func(client Client, args ...any) (details []Detail, err error) {
+		var items []Item
+		if listBinding.Paginated() {
+			var paginator Paginator[ListResT, []Item]
+			if paginator, err = NewTypedPaginator(client, 0, listBinding, args...); err != nil {
+				return nil, errors.Wrapf(err, "could not paginate list Binding %q", listBinding.Name())
+			}
+			items, err = paginator.All()
+		} else {
+			items, err = listBinding.Execute(client, args...)
+		}
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not list items using Binding %q", listBinding.Name())
+		}
+
+		details = make([]Detail, len(items))
+		var (
+			wg       sync.WaitGroup
+			errOnce  sync.Once
+			firstErr error
+			failed   = make(chan struct{})
+			sem      = make(chan struct{}, concurrency)
+		)
+		fail := func(err error) {
+			errOnce.Do(func() {
+				firstErr = err
+				close(failed)
+			})
+		}
+
+	schedule:
+		for i, item := range items {
+			// Wait for a free slot, unless a detail Binding has already failed
+			select {
+			case <-failed:
+				break schedule
+			case sem <- struct{}{}:
+			}
+
+			wg.Add(1)
+			go func(i int, item Item) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				detail, detailErr := detailBinding.Execute(client, key(item)...)
+				if detailErr != nil {
+					fail(errors.Wrapf(detailErr, "could not fetch the details of item no. %d using Binding %q", i, detailBinding.Name()))
+					return
+				}
+				details[i] = detail
+			}(i, item)
+		}
+		wg.Wait()
+
+		if firstErr != nil {
+			return nil, firstErr
+		}
+		return details, nil
+	}
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// concurrentClient is a Client that can be used from multiple goroutines, which records the maximum number of
+// concurrent executions of the Binding with the given name.
+type concurrentClient struct {
+	run     func(bindingName string, args []any) (any, error)
+	tracked string
+	mutex   sync.Mutex
+	active  int
+	maxSeen int
+}
+
+func (c *concurrentClient) Run(ctx context.Context, bindingName string, attrs map[string]any, req Request, res any) error {
+	if bindingName == c.tracked {
+		c.mutex.Lock()
+		if c.active++; c.active > c.maxSeen {
+			c.maxSeen = c.active
+		}
+		c.mutex.Unlock()
+
+		defer func() {
+			c.mutex.Lock()
+			c.active--
+			c.mutex.Unlock()
+		}()
+		time.Sleep(time.Millisecond * 5)
+	}
+
+	response, err := c.run(bindingName, req.(*mockRequest).args)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, res)
+}
+
+func TestExpandEach(t *testing.T) {
+	type shallow struct {
+		ID int `json:"id"`
+	}
+
+	type detail struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	client := &concurrentClient{tracked: "user", run: func(bindingName string, args []any) (any, error) {
+		switch bindingName {
+		case "users":
+			// The list endpoint returns at most 2 shallow items per page, out of 5 items
+			page := args[0].(int)
+			items := make([]shallow, 0)
+			for id := (page-1)*2 + 1; id <= page*2 && id <= 5; id++ {
+				items = append(items, shallow{ID: id})
+			}
+			return items, nil
+		default:
+			id := args[0].(int)
+			if id == 13 {
+				return nil, fmt.Errorf("user %d not found", id)
+			}
+			return detail{ID: id, Name: fmt.Sprintf("user%d", id)}, nil
+		}
+	}}
+
+	list := NewBindingChain(mockRequestMethod[[]shallow, []shallow]).SetParamsMethod(func(binding Binding[[]shallow, []shallow]) []BindingParam {
+		return Params("page", 1, true)
+	}).SetPaginated(true).SetName("users")
+	get := NewBindingChain(mockRequestMethod[detail, detail]).SetParamsMethod(func(binding Binding[detail, detail]) []BindingParam {
+		return Params("id", 0, true)
+	}).SetName("user")
+
+	expand := ExpandEach(list, get, func(item shallow) []any { return []any{item.ID} }, 2)
+	details, err := expand(client)
+	if err != nil {
+		t.Fatalf("could not expand users: %v", err)
+	}
+
+	expected := []detail{{1, "user1"}, {2, "user2"}, {3, "user3"}, {4, "user4"}, {5, "user5"}}
+	if !reflect.DeepEqual(details, expected) {
+		t.Errorf("expected details %v, not %v", expected, details)
+	}
+
+	if client.maxSeen > 2 {
+		t.Errorf("expected at most 2 concurrent executions of the detail Binding, not %d", client.maxSeen)
+	}
+
+	// An error from a detail Binding should be returned
+	failing := ExpandEach(list, get, func(item shallow) []any { return []any{item.ID + 10} }, 2)
+	if _, err = failing(client); err == nil {
+		t.Errorf("expected an error when a detail Binding fails")
+	}
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"strings"
+)
+
+// FieldsAttrKey is the key of the Attr that is added to a Binding by Binding.SetFields. The value of the Attr is the
+// []string of fields that the Binding needs from the response.
+const FieldsAttrKey = "fields"
+
+// FieldsQueryParam is the name of the query param that the fields set by Binding.SetFields are added to by a Binding
+// created using NewRESTBinding. The fields are joined by commas, such as "fields=id,name,owner.login".
+const FieldsQueryParam = "fields"
+
+// GraphQLFieldsPlaceholder is the placeholder within the query of a Binding created using NewGraphQLBinding that is
+// replaced by the selection set for the fields set by Binding.SetFields. For example, the query:
+//
+//	query { user(id: 1) { {{fields}} } }
+//
+// with the fields "id", "name", and "owner.login" will be executed as:
+//
+//	query { user(id: 1) { id name owner { login } } }
+const GraphQLFieldsPlaceholder = "{{fields}}"
+
+// fieldsFromAttrs returns the fields set by Binding.SetFields within the given attrs.
+func fieldsFromAttrs(attrs map[string]any) []string {
+	fields, _ := attrs[FieldsAttrKey].([]string)
+	return fields
+}
+
+// GraphQLSelectionSet returns the GraphQL selection set for the given fields. Nested fields are given as dot-separated
+// paths, such as "owner.login", and are grouped by their parent field in the order that the parent first appears.
+func GraphQLSelectionSet(fields ...string) string {
+	type node struct {
+		name     string
+		children []*node
+	}
+
+	root := &node{}
+	for _, field := range fields {
+		current := root
+		for _, name := range strings.Split(field, ".") {
+			var next *node
+			for _, child := range current.children {
+				if child.name == name {
+					next = child
+					break
+				}
+			}
+
+			if next == nil {
+				next = &node{name: name}
+				current.children = append(current.children, next)
+			}
+			current = next
+		}
+	}
+
+	var render func(nodes []*node) string
+	render = func(nodes []*node) string {
+		selections := make([]string, len(nodes))
+		for i, n := range nodes {
+			selections[i] = n.name
+			if len(n.children) > 0 {
+				selections[i] += " { " + render(n.children) + " }"
+			}
+		}
+		return strings.Join(selections, " ")
+	}
+	return render(root.children)
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestBindingProto_SetFields(t *testing.T) {
+	var query string
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		switch req := req.(type) {
+		case HTTPRequest:
+			query = req.URL.Query().Get(FieldsQueryParam)
+		case RecordableGraphQLRequest:
+			query = req.Query()
+		}
+		return "ok", nil
+	}}
+
+	rest := NewRESTBinding[string, string](http.MethodGet, "https://example.com/repos", nil, false).SetFields("id", "name", "owner.login")
+	if _, err := rest.Execute(client); err != nil {
+		t.Fatalf("could not execute REST Binding: %v", err)
+	}
+
+	if expected := "id,name,owner.login"; query != expected {
+		t.Errorf("expected the %q query param to be %q, not %q", FieldsQueryParam, expected, query)
+	}
+
+	gql := NewGraphQLBinding[string, string](
+		"query { repo(id: 1) { "+GraphQLFieldsPlaceholder+" } }", nil, nil, false,
+	).SetFields("id", "owner.login", "name", "owner.id")
+	if _, err := gql.Execute(client); err != nil {
+		t.Fatalf("could not execute GraphQL Binding: %v", err)
+	}
+
+	if expected := "query { repo(id: 1) { id owner { login id } name } }"; query != expected {
+		t.Errorf("expected the GraphQL query to be %q, not %q", expected, query)
+	}
+}
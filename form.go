@@ -0,0 +1,98 @@
+package api
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// formFieldName returns the name of the form value for the given struct field. This is taken from the "form" tag, then
+// the "url" tag, and then the name of the field itself. A name of "-" means that the field should be skipped.
+func formFieldName(field reflect.StructField) string {
+	for _, key := range []string{"form", "url"} {
+		if tag, ok := field.Tag.Lookup(key); ok {
+			if name, _, _ := strings.Cut(tag, ","); name != "" {
+				return name
+			}
+		}
+	}
+	return field.Name
+}
+
+// FormDecoder is a Decoder that parses application/x-www-form-urlencoded bodies, such as those returned by some OAuth
+// token endpoints. The body can be decoded into:
+//   - url.Values, map[string][]string, or map[string]string (only the first value for each key is kept).
+//   - map[string]any: keys with a single value are set to a string, and repeated keys are set to a []string.
+//   - A struct: each exported field is set from the value whose name matches the field's "form" tag, "url" tag, or
+//     name (in that order). Values are parsed into the type of the field in the same way as
+//     Binding.ArgsFromStrings, and []string fields are set from repeated keys.
+//
+// FormDecoder can be used with a HTTPClient like so:
+//
+//	client := NewHTTPClient().SetDecoder(FormDecoder)
+func FormDecoder(data []byte, v any) (err error) {
+	var values url.Values
+	if values, err = url.ParseQuery(string(data)); err != nil {
+		return errors.Wrap(err, "could not parse form-urlencoded body")
+	}
+
+	// Binding.Execute passes a pointer to the interface holding the response wrapper
+	if wrapper, ok := v.(*any); ok && wrapper != nil {
+		v = *wrapper
+	}
+
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Pointer || val.IsNil() {
+		return fmt.Errorf("cannot decode form-urlencoded body into non-pointer %T", v)
+	}
+
+	switch target := v.(type) {
+	case *url.Values:
+		*target = values
+	case *map[string][]string:
+		*target = values
+	case *map[string]string:
+		*target = make(map[string]string, len(values))
+		for key := range values {
+			(*target)[key] = values.Get(key)
+		}
+	case *map[string]any:
+		*target = make(map[string]any, len(values))
+		for key, vals := range values {
+			if len(vals) == 1 {
+				(*target)[key] = vals[0]
+			} else {
+				(*target)[key] = vals
+			}
+		}
+	default:
+		elem := val.Elem()
+		if elem.Kind() != reflect.Struct {
+			return fmt.Errorf("cannot decode form-urlencoded body into %T", v)
+		}
+
+		for i := 0; i < elem.NumField(); i++ {
+			field := elem.Type().Field(i)
+			name := formFieldName(field)
+			vals, ok := values[name]
+			if !field.IsExported() || name == "-" || !ok {
+				continue
+			}
+
+			fieldVal := elem.Field(i)
+			if fieldVal.Kind() == reflect.Slice && fieldVal.Type().Elem().Kind() == reflect.String {
+				fieldVal.Set(reflect.ValueOf(vals).Convert(fieldVal.Type()))
+				continue
+			}
+
+			var parsed any
+			if parsed, err = parseArg(fieldVal.Type(), vals[0]); err != nil {
+				return errors.Wrapf(err, "could not parse form value %q for field %s", name, field.Name)
+			}
+			fieldVal.Set(reflect.ValueOf(parsed))
+		}
+	}
+	return
+}
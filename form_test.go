@@ -0,0 +1,56 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestFormDecoder(t *testing.T) {
+	type token struct {
+		AccessToken string   `form:"access_token"`
+		ExpiresIn   int      `url:"expires_in"`
+		TokenType   string   `form:"token_type,omitempty"`
+		Scope       []string `form:"scope"`
+		Ignored     string   `form:"-"`
+	}
+
+	const body = "access_token=abc123&expires_in=3600&token_type=bearer&scope=read&scope=write&Ignored=x"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-www-form-urlencoded")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient().SetDecoder(FormDecoder)
+	actual, err := NewRESTBinding[token, token](http.MethodPost, server.URL, nil, false).Execute(client)
+	if err != nil {
+		t.Fatalf("could not execute Binding: %v", err)
+	}
+
+	expected := token{AccessToken: "abc123", ExpiresIn: 3600, TokenType: "bearer", Scope: []string{"read", "write"}}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %+v, not %+v", expected, actual)
+	}
+
+	for testNo, test := range []struct {
+		target   any
+		expected any
+	}{
+		{new(url.Values), &url.Values{"access_token": {"abc123"}, "expires_in": {"3600"}, "token_type": {"bearer"}, "scope": {"read", "write"}, "Ignored": {"x"}}},
+		{new(map[string]string), &map[string]string{"access_token": "abc123", "expires_in": "3600", "token_type": "bearer", "scope": "read", "Ignored": "x"}},
+		{new(map[string]any), &map[string]any{"access_token": "abc123", "expires_in": "3600", "token_type": "bearer", "scope": []string{"read", "write"}, "Ignored": "x"}},
+	} {
+		if err = FormDecoder([]byte(body), test.target); err != nil {
+			t.Errorf("test no. %d could not decode into %T: %v", testNo+1, test.target, err)
+		} else if !reflect.DeepEqual(test.target, test.expected) {
+			t.Errorf("test no. %d expected %v, not %v", testNo+1, test.expected, test.target)
+		}
+	}
+
+	if err = FormDecoder([]byte("expires_in=soon"), new(token)); err == nil {
+		t.Errorf("expected an error when a value cannot be parsed into the type of its field")
+	}
+}
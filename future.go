@@ -0,0 +1,38 @@
+package api
+
+import (
+	"fmt"
+)
+
+// Future is the result of an asynchronous execution, such as Binding.ExecuteAsync.
+type Future[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// newFuture calls the given function within a new goroutine, and returns a Future for its result. If the function
+// panics, then the panic is recovered and returned as an error from Future.Wait.
+func newFuture[T any](fn func() (T, error)) *Future[T] {
+	f := &Future[T]{done: make(chan struct{})}
+	go func() {
+		defer close(f.done)
+		defer func() {
+			if p := recover(); p != nil {
+				f.err = fmt.Errorf("panic occurred during asynchronous execution: %v", p)
+			}
+		}()
+		f.value, f.err = fn()
+	}()
+	return f
+}
+
+// Wait blocks until the execution has finished, then returns its result. Wait can be called multiple times, and from
+// multiple goroutines.
+func (f *Future[T]) Wait() (T, error) {
+	<-f.done
+	return f.value, f.err
+}
+
+// Done returns a channel that is closed once the execution has finished.
+func (f *Future[T]) Done() <-chan struct{} { return f.done }
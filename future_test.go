@@ -0,0 +1,53 @@
+package api
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBindingProto_ExecuteAsync(t *testing.T) {
+	var requests atomic.Int64
+	run := func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		requests.Add(1)
+		n := req.(*mockRequest).args[0].(int)
+		if n < 0 {
+			panic("negative number")
+		}
+		time.Sleep(time.Duration(n) * time.Millisecond)
+		return n * n, nil
+	}
+
+	binding := NewBindingChain(mockRequestMethod[int, int]).SetParamsMethod(func(binding Binding[int, int]) []BindingParam {
+		return Params("n", 0, true)
+	})
+
+	// mockClient.Run isn't safe for concurrent use, so each execution is given its own mockClient
+	futures := make([]*Future[int], 0)
+	for n := 1; n <= 5; n++ {
+		futures = append(futures, binding.ExecuteAsync(&mockClient{run: run}, n))
+	}
+
+	for i, future := range futures {
+		select {
+		case <-future.Done():
+		case <-time.After(time.Second):
+			t.Fatalf("future no. %d did not finish in time", i+1)
+		}
+
+		if actual, err := future.Wait(); err != nil || actual != (i+1)*(i+1) {
+			t.Errorf("future no. %d expected (%d, nil), not (%d, %v)", i+1, (i+1)*(i+1), actual, err)
+		}
+	}
+
+	if requests.Load() != 5 {
+		t.Errorf("expected 5 requests, not %d", requests.Load())
+	}
+
+	// Panics should be returned as errors
+	if _, err := binding.ExecuteAsync(&mockClient{run: run}, -1).Wait(); err == nil || !strings.Contains(err.Error(), "negative number") {
+		t.Errorf("expected the panic to be returned as an error, got %v", err)
+	}
+}
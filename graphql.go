@@ -0,0 +1,107 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"reflect"
+	"strings"
+)
+
+// GraphQLVarsFromArgs maps the arguments passed to Binding.Execute for a Binding created with NewGraphQLBinding to the
+// variables of the RecordableGraphQLRequest. It is given the Params of the Binding, along with the arguments after they have been
+// type-checked against those Params.
+type GraphQLVarsFromArgs func(params []BindingParam, args []any) map[string]any
+
+// GraphQLVarsFromParams is the default GraphQLVarsFromArgs for NewGraphQLBinding. Each argument is set as the variable
+// with the same name as its BindingParam, and the arguments for a variadic BindingParam are collected into a single
+// slice variable. If the Binding has a single BindingParam, and its argument is a struct (or a pointer to a struct),
+// then the struct is marshalled to JSON and each of its fields becomes a variable instead. This means that the names of
+// the variables can be controlled using "json" struct tags:
+//
+//	type UserFilter struct {
+//		Login string `json:"login"`
+//		First int    `json:"first,omitempty"`
+//	}
+//
+// Structs that cannot be marshalled to a JSON object are set as a variable with the same name as the BindingParam.
+func GraphQLVarsFromParams(params []BindingParam, args []any) map[string]any {
+	vars := make(map[string]any)
+	if len(params) == 1 && !params[0].variadic && len(args) == 1 {
+		if structVars, ok := graphQLVarsFromStruct(args[0]); ok {
+			return structVars
+		}
+	}
+
+	for i, arg := range args {
+		switch {
+		case i < len(params) && !params[i].variadic:
+			vars[params[i].name] = arg
+		case len(params) > 0 && params[len(params)-1].variadic:
+			name := params[len(params)-1].name
+			variadic, _ := vars[name].([]any)
+			vars[name] = append(variadic, arg)
+		}
+	}
+	return vars
+}
+
+// graphQLVarsFromStruct marshals the given struct (or pointer to a struct) to a JSON object, and returns its fields as
+// GraphQL variables. Numbers are decoded as json.Number so that they are marshalled back to the same JSON. The second
+// return value is false if the given value is not a struct, or if it cannot be marshalled to a JSON object.
+func graphQLVarsFromStruct(arg any) (vars map[string]any, ok bool) {
+	val := reflect.ValueOf(arg)
+	for val.Kind() == reflect.Pointer && !val.IsNil() {
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	data, err := json.Marshal(arg)
+	if err != nil {
+		return nil, false
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err = decoder.Decode(&vars); err != nil || vars == nil {
+		return nil, false
+	}
+	return vars, true
+}
+
+// NewGraphQLBinding creates a new Binding for a GraphQL operation. The Request for the Binding is a
+// RecordableGraphQLRequest (see NewGraphQLRequest) for the given query, whose variables are populated from the
+// arguments passed to Binding.Execute using the given GraphQLVarsFromArgs. Because the arguments are type-checked against the Params of the Binding before the Request is
+// constructed, each variable is type-checked by its BindingParam. If varsFromArgs is nil, then GraphQLVarsFromParams is
+// used, which names each variable after its BindingParam. The GraphQLFieldsPlaceholder can be used within the query to
+// insert the selection set for the fields set by Binding.SetFields.
+//
+// The params, paginated, and attrs parameters are the same as those of NewBinding.
+func NewGraphQLBinding[ResT any, RetT any](
+	query string,
+	varsFromArgs GraphQLVarsFromArgs,
+	params BindingParamsMethod[ResT, RetT],
+	paginated bool,
+	attrs ...Attr,
+) Binding[ResT, RetT] {
+	if varsFromArgs == nil {
+		varsFromArgs = GraphQLVarsFromParams
+	}
+
+	return NewBinding[ResT, RetT](nil, nil, nil, nil, params, paginated, attrs...).SetRequestMethodE(func(binding Binding[ResT, RetT], args ...any) (request Request, err error) {
+		// The fields are substituted before the graphql.Request is created, as its query cannot be modified afterwards
+		q := query
+		if fields := fieldsFromAttrs(binding.Attrs()); len(fields) > 0 {
+			q = strings.ReplaceAll(q, GraphQLFieldsPlaceholder, GraphQLSelectionSet(fields...))
+		}
+
+		vars := varsFromArgs(binding.Params(), args)
+		if _, ok := vars[""]; ok {
+			return nil, errors.New("cannot set GraphQL variable with an empty name")
+		}
+		return NewGraphQLRequest(q, vars), nil
+	})
+}
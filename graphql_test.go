@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestNewGraphQLBinding(t *testing.T) {
+	type userFilter struct {
+		Login string `json:"login"`
+		First int    `json:"first,omitempty"`
+	}
+
+	var vars map[string]any
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		vars = req.(RecordableGraphQLRequest).Vars()
+		return map[string]any{"ok": true}, nil
+	}}
+
+	for testNo, test := range []struct {
+		binding      Binding[map[string]any, map[string]any]
+		args         []any
+		expectedVars map[string]any
+		expectedErr  bool
+	}{
+		{
+			binding: NewGraphQLBinding[map[string]any, map[string]any](
+				"query User($login: String!, $first: Int) { user(login: $login) { id } }",
+				nil,
+				func(binding Binding[map[string]any, map[string]any]) []BindingParam {
+					return Params("login", "", true, "first", 10)
+				}, false,
+			),
+			args:         []any{"andygello555"},
+			expectedVars: map[string]any{"login": "andygello555", "first": 10},
+		},
+		{
+			binding: NewGraphQLBinding[map[string]any, map[string]any](
+				"query Users($ids: [ID!]!) { nodes(ids: $ids) { id } }",
+				nil,
+				func(binding Binding[map[string]any, map[string]any]) []BindingParam {
+					return []BindingParam{VarParam("ids", []string{})}
+				}, false,
+			),
+			args:         []any{"1", "2"},
+			expectedVars: map[string]any{"ids": []any{"1", "2"}},
+		},
+		{
+			binding: NewGraphQLBinding[map[string]any, map[string]any](
+				"query User($login: String!, $first: Int) { user(login: $login) { id } }",
+				nil,
+				func(binding Binding[map[string]any, map[string]any]) []BindingParam {
+					return Params("filter", userFilter{}, true)
+				}, false,
+			),
+			args:         []any{userFilter{Login: "andygello555", First: 5}},
+			expectedVars: map[string]any{"login": "andygello555", "first": json.Number("5")},
+		},
+		{
+			binding: NewGraphQLBinding[map[string]any, map[string]any](
+				"query User($user: String!) { user(login: $user) { id } }",
+				func(params []BindingParam, args []any) map[string]any {
+					return map[string]any{"user": args[0]}
+				},
+				func(binding Binding[map[string]any, map[string]any]) []BindingParam {
+					return Params("login", "", true)
+				}, false,
+			),
+			args:         []any{"andygello555"},
+			expectedVars: map[string]any{"user": "andygello555"},
+		},
+		{
+			// The arguments are type-checked against the params before the variables are populated
+			binding: NewGraphQLBinding[map[string]any, map[string]any](
+				"query User($login: String!) { user(login: $login) { id } }",
+				nil,
+				func(binding Binding[map[string]any, map[string]any]) []BindingParam {
+					return Params("login", "", true)
+				}, false,
+			),
+			args:        []any{1},
+			expectedErr: true,
+		},
+	} {
+		vars = nil
+		_, err := test.binding.Execute(client, test.args...)
+		switch {
+		case test.expectedErr:
+			if err == nil {
+				t.Errorf("test no. %d expected an error", testNo+1)
+			}
+		case err != nil:
+			t.Errorf("test no. %d could not execute Binding: %v", testNo+1, err)
+		case !reflect.DeepEqual(vars, test.expectedVars):
+			t.Errorf("test no. %d expected variables %v, not %v", testNo+1, test.expectedVars, vars)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// namedArgsFromRequest parses the named arguments for the given BindingParam(s) from the query parameters and JSON body
+// of the given http.Request. Arguments within the JSON body take precedence over query parameters.
+func namedArgsFromRequest(params []BindingParam, r *http.Request) (named map[string]any, err error) {
+	named = make(map[string]any)
+	paramsByName := make(map[string]BindingParam)
+	for _, param := range params {
+		paramsByName[param.name] = param
+	}
+
+	for name, values := range r.URL.Query() {
+		param, ok := paramsByName[name]
+		if !ok {
+			return named, paramErrorf(name, -1, "param %q does not exist", name)
+		}
+
+		if param.variadic {
+			elems := reflect.MakeSlice(param.Type(), 0, len(values))
+			for _, value := range values {
+				var elem any
+				if elem, err = parseArg(param.Type().Elem(), value); err != nil {
+					return named, &ParamError{Param: name, No: -1, err: errors.Wrapf(err, "could not parse query param %q", name)}
+				}
+				elems = reflect.Append(elems, reflect.ValueOf(elem))
+			}
+			named[name] = elems.Interface()
+			continue
+		}
+
+		if named[name], err = parseArg(param.Type(), values[0]); err != nil {
+			return named, &ParamError{Param: name, No: -1, err: errors.Wrapf(err, "could not parse query param %q", name)}
+		}
+	}
+
+	if r.Body == nil || r.ContentLength == 0 || !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		return
+	}
+
+	var body map[string]json.RawMessage
+	if err = json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return named, &ParamError{Param: "", No: -1, err: errors.Wrap(err, "could not decode JSON body")}
+	}
+
+	for name, raw := range body {
+		param, ok := paramsByName[name]
+		if !ok {
+			return named, paramErrorf(name, -1, "param %q does not exist", name)
+		}
+
+		val := reflect.New(param.Type())
+		if err = json.Unmarshal(raw, val.Interface()); err != nil {
+			return named, &ParamError{Param: name, No: -1, err: errors.Wrapf(err, "could not decode JSON field %q", name)}
+		}
+		named[name] = val.Elem().Interface()
+	}
+	return
+}
+
+func writeJSONError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
+
+// BindingHandler returns a http.HandlerFunc that executes the given BindingWrapper using the given Client. Arguments
+// for the Binding are read from the query parameters and JSON body of the http.Request, and are mapped to the
+// BindingParam(s) of the Binding by name (see BindingWrapper.ExecuteNamed). The value returned by the Binding is
+// written to the http.ResponseWriter as JSON.
+//
+// If an argument cannot be parsed, or is otherwise invalid (i.e. a ParamError is returned), then a
+// http.StatusBadRequest will be written. Any other error that occurs when executing the Binding will write a
+// http.StatusBadGateway. Errors are written as a JSON object of the form:
+//
+//	{"error": "<error message>"}
+func BindingHandler(bw BindingWrapper, client Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		named, err := namedArgsFromRequest(bw.Params(), r)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		var val any
+		if val, err = bw.ExecuteNamed(client, named); err != nil {
+			var paramErr *ParamError
+			if errors.As(err, &paramErr) {
+				writeJSONError(w, http.StatusBadRequest, err)
+			} else {
+				writeJSONError(w, http.StatusBadGateway, err)
+			}
+			return
+		}
+
+		var body []byte
+		if body, err = json.Marshal(val); err != nil {
+			writeJSONError(w, http.StatusInternalServerError, errors.Wrapf(err, "could not marshal response for Binding %q", bw.Name()))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBindingHandler(t *testing.T) {
+	type sum struct {
+		Name  string `json:"name"`
+		Total int    `json:"total"`
+	}
+
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		args := req.(*mockRequest).args
+		s := sum{Name: args[0].(string)}
+		for _, arg := range args[1:] {
+			s.Total += arg.(int)
+		}
+		return s, nil
+	}}
+
+	bw := WrapBinding(NewBindingChain(mockRequestMethod[sum, sum]).SetParamsMethod(func(binding Binding[sum, sum]) []BindingParam {
+		return Params("name", "", true, "numbers", []int{}, false, true)
+	}).SetName("sum"))
+
+	server := httptest.NewServer(BindingHandler(bw, client))
+	defer server.Close()
+
+	for testNo, test := range []struct {
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{http.MethodGet, "/?name=query&numbers=1&numbers=2&numbers=3", "", http.StatusOK, `{"name":"query","total":6}`},
+		{http.MethodPost, "/", `{"name": "body", "numbers": [4, 5]}`, http.StatusOK, `{"name":"body","total":9}`},
+		{http.MethodGet, "/?numbers=1", "", http.StatusBadRequest, `required param \"name\"`},
+		{http.MethodGet, "/?name=a&numbers=a", "", http.StatusBadRequest, `could not parse query param \"numbers\"`},
+		{http.MethodGet, "/?name=a&unknown=1", "", http.StatusBadRequest, `param \"unknown\" does not exist`},
+	} {
+		req, _ := http.NewRequest(test.method, server.URL+test.path, strings.NewReader(test.body))
+		if test.body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("test no. %d could not make request: %v", testNo+1, err)
+		}
+
+		var body json.RawMessage
+		_ = json.NewDecoder(res.Body).Decode(&body)
+		_ = res.Body.Close()
+
+		if res.StatusCode != test.expectedStatus {
+			t.Errorf("test no. %d expected status %d, not %d (%s)", testNo+1, test.expectedStatus, res.StatusCode, body)
+		}
+
+		if !strings.Contains(string(body), test.expectedBody) {
+			t.Errorf("test no. %d expected body to contain %s, got %s", testNo+1, test.expectedBody, body)
+		}
+	}
+}
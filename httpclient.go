@@ -0,0 +1,375 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Decoder decodes the given response body into the given value. The default Decoder for a HTTPClient is
+// json.Unmarshal. A different Decoder can be set using HTTPClient.SetDecoder.
+type Decoder func(data []byte, v any) error
+
+// JSONDecoderUseNumber is a Decoder that decodes JSON using a json.Decoder with json.Decoder.UseNumber set. Numbers
+// that are decoded into an interface value (such as the values of a map[string]any) will be decoded as json.Number
+// rather than float64, which prevents large integer IDs (beyond 2^53) from losing precision. It can be used with a
+// HTTPClient like so:
+//
+//	client := NewHTTPClient().SetDecoder(JSONDecoderUseNumber)
+func JSONDecoderUseNumber(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// ResponseDecoder can be implemented by a response type (ResT), or by the response wrapper of a Binding, so that it can
+// decode its own response body. This allows a response type to use non-standard wire formats, such as msgpack or
+// protobuf-json. When the value that HTTPClient.Run decodes into implements ResponseDecoder, DecodeResponse is called
+// instead of the Decoder of the HTTPClient. DecodeResponse should usually be implemented with a pointer receiver.
+type ResponseDecoder interface {
+	DecodeResponse(body []byte) error
+}
+
+// HTTPError is the error returned by HTTPClient.Run when the response has a non-2XX status code. HTTPError implements
+// RetryAfterError, so the Retry-After header of the response will be respected by RetryPolicy.
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	Header     http.Header
+	Body       []byte
+}
+
+func (err *HTTPError) Error() string {
+	return fmt.Sprintf("request returned non-2XX status code %s: %s", err.Status, string(err.Body))
+}
+
+// RetryAfter returns the duration given by the Retry-After header of the response. The header can either be a number
+// of seconds or a HTTP date. 0 is returned if there is no valid Retry-After header.
+func (err *HTTPError) RetryAfter() time.Duration {
+	retryAfter := err.Header.Get("Retry-After")
+	if retryAfter == "" {
+		return 0
+	}
+
+	if seconds, parseErr := strconv.Atoi(retryAfter); parseErr == nil {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, parseErr := http.ParseTime(retryAfter); parseErr == nil {
+		return time.Until(date)
+	}
+	return 0
+}
+
+// HTTPClient is a generic Client that executes HTTPRequest(s) using a http.Client, and decodes each response body
+// using its Decoder. HTTPClient also implements HeaderRecorder and MetaRecorder, so it can be used with
+// LinkHeaderPagination and Binding.ExecuteWithResponse. Responses with a gzip Content-Encoding are decompressed before
+// they are decoded, which allows a Binding to request compressed responses using Binding.SetAcceptEncoding.
+type HTTPClient struct {
+	client         *http.Client
+	decoder        Decoder
+	contentDecoder map[string]Decoder
+	responseCache  ResponseCache
+	metas          sync.Map
+}
+
+// NewHTTPClient creates a new HTTPClient that uses http.DefaultClient and decodes responses using json.Unmarshal.
+// Responses with an XML Content-Type ("application/xml", "text/xml", or a "+xml" suffix) are decoded using
+// xml.Unmarshal. A custom http.Client or http.RoundTripper can be set using SetHTTPClient and SetTransport.
+func NewHTTPClient() *HTTPClient {
+	return &HTTPClient{
+		client:  http.DefaultClient,
+		decoder: json.Unmarshal,
+		contentDecoder: map[string]Decoder{
+			"application/xml": xml.Unmarshal,
+			"text/xml":        xml.Unmarshal,
+		},
+	}
+}
+
+// SetHTTPClient sets the http.Client that is used to execute each HTTPRequest. This allows a tuned http.Client to be
+// used, such as one with timeouts, a proxy, or a http.Transport with its own connection pool and TLS config. If the
+// given http.Client is nil, then http.DefaultClient is used.
+func (c *HTTPClient) SetHTTPClient(client *http.Client) *HTTPClient {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	c.client = client
+	return c
+}
+
+// SetTransport sets the http.RoundTripper used by the http.Client of the HTTPClient. The http.Client is copied before
+// its Transport is set, so that a shared http.Client, such as http.DefaultClient, is not modified.
+func (c *HTTPClient) SetTransport(transport http.RoundTripper) *HTTPClient {
+	client := *c.client
+	client.Transport = transport
+	c.client = &client
+	return c
+}
+
+// SetResponseCache sets the ResponseCache used to make conditional requests. Responses to requests with a safe method
+// (such as GET or HEAD) that have an ETag header are stored within the ResponseCache once their body has been read in
+// full. Subsequent requests for the same method and URL will send the ETag within an If-None-Match header, and if the
+// server responds with 304 (Not Modified) then the cached body is decoded instead. The ResponseMeta recorded for such a
+// response will have the 304 status code.
+func (c *HTTPClient) SetResponseCache(cache ResponseCache) *HTTPClient {
+	c.responseCache = cache
+	return c
+}
+
+// SetDecoder sets the Decoder used to decode response bodies, such as JSONDecoderUseNumber.
+func (c *HTTPClient) SetDecoder(decoder Decoder) *HTTPClient {
+	c.decoder = decoder
+	return c
+}
+
+// SetContentDecoder registers the Decoder that is used to decode responses with the given media type within their
+// Content-Type header, such as "text/csv". Media types with a structured syntax suffix, such as "application/ld+json",
+// will fall back to the Decoder registered for the suffix, such as "application/json". Responses with a Content-Type
+// that has no registered Decoder are decoded using the Decoder set by SetDecoder.
+func (c *HTTPClient) SetContentDecoder(mediaType string, decoder Decoder) *HTTPClient {
+	c.contentDecoder[strings.ToLower(mediaType)] = decoder
+	return c
+}
+
+// decoderFor returns the Decoder for the given Content-Type header.
+func (c *HTTPClient) decoderFor(contentType string) Decoder {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return c.decoder
+	}
+
+	if decoder, ok := c.contentDecoder[mediaType]; ok {
+		return decoder
+	}
+
+	if i := strings.LastIndex(mediaType, "+"); i >= 0 {
+		if decoder, ok := c.contentDecoder["application/"+mediaType[i+1:]]; ok {
+			return decoder
+		}
+	}
+	return c.decoder
+}
+
+// do executes the given Request, which must be a HTTPRequest, and records the ResponseMeta of the response. A HTTPError
+// is returned if the response has a non-2XX status code. Otherwise, the caller must close the body of the response. If
+// a ResponseCache has been set, then the request is made conditional on the ETag of the cached response.
+func (c *HTTPClient) do(ctx context.Context, bindingName string, req Request) (response *http.Response, err error) {
+	httpRequest, ok := req.(HTTPRequest)
+	if !ok || httpRequest.Request == nil {
+		return nil, fmt.Errorf("HTTPClient can only execute a non-nil HTTPRequest, not %T", req)
+	}
+
+	request := httpRequest.Request.WithContext(ctx)
+	var (
+		cacheKey  string
+		cached    CachedResponse
+		hasCached bool
+	)
+	cacheable := c.responseCache != nil && isSafeMethod(request.Method)
+	if cacheable {
+		cacheKey = responseCacheKey(request)
+		if cached, hasCached, err = c.responseCache.Get(cacheKey); err != nil {
+			return nil, err
+		}
+
+		if hasCached = hasCached && cached.ETag != ""; hasCached {
+			request = request.Clone(ctx)
+			request.Header.Set("If-None-Match", cached.ETag)
+		}
+	}
+
+	if response, err = c.client.Do(request); err != nil {
+		return nil, err
+	}
+	decompressResponse(response)
+
+	notModified := hasCached && response.StatusCode == http.StatusNotModified
+	if notModified {
+		_ = response.Body.Close()
+		header := cached.Header.Clone()
+		if header == nil {
+			header = make(http.Header)
+		}
+		for key, values := range response.Header {
+			header[key] = values
+		}
+		response.Header = header
+		response.Body = io.NopCloser(bytes.NewReader(cached.Body))
+	}
+
+	c.metas.Store(bindingName, ResponseMeta{
+		StatusCode: response.StatusCode,
+		Header:     response.Header,
+	})
+
+	if !notModified && (response.StatusCode < 200 || response.StatusCode >= 300) {
+		defer response.Body.Close()
+		body, _ := io.ReadAll(response.Body)
+		return nil, &HTTPError{
+			StatusCode: response.StatusCode,
+			Status:     response.Status,
+			Header:     response.Header,
+			Body:       body,
+		}
+	}
+
+	if etag := response.Header.Get("ETag"); cacheable && !notModified && etag != "" {
+		response.Body = &cachingBody{
+			ReadCloser: response.Body,
+			cache:      c.responseCache,
+			key:        cacheKey,
+			response:   CachedResponse{ETag: etag, Header: response.Header},
+		}
+	}
+	return
+}
+
+// gzipBody decompresses a gzip-encoded response body. The gzip.Reader is created lazily on the first Read, as
+// gzip.NewReader reads the gzip header straight away, which would fail for responses that have no body.
+type gzipBody struct {
+	io.ReadCloser
+	reader *gzip.Reader
+	err    error
+}
+
+func (b *gzipBody) Read(p []byte) (n int, err error) {
+	if b.reader == nil && b.err == nil {
+		if b.reader, b.err = gzip.NewReader(b.ReadCloser); b.err != nil && b.err != io.EOF {
+			b.err = errors.Wrap(b.err, "could not decompress gzip response body")
+		}
+	}
+
+	if b.err != nil {
+		return 0, b.err
+	}
+	return b.reader.Read(p)
+}
+
+// decompressResponse replaces the body of the given http.Response with a gzipBody if it has a gzip Content-Encoding.
+// The http.Transport only decompresses responses transparently when it has set the Accept-Encoding header itself, so
+// this handles responses to requests that set the Accept-Encoding header explicitly. The Content-Encoding and
+// Content-Length headers are removed, as they no longer describe the body. Responses from servers that ignore the
+// Accept-Encoding header are left as is.
+func decompressResponse(response *http.Response) {
+	switch strings.ToLower(strings.TrimSpace(response.Header.Get("Content-Encoding"))) {
+	case "gzip", "x-gzip":
+		response.Body = &gzipBody{ReadCloser: response.Body}
+		response.Header.Del("Content-Encoding")
+		response.Header.Del("Content-Length")
+		response.ContentLength = -1
+		response.Uncompressed = true
+	}
+}
+
+// Run executes the given Request, which must be a HTTPRequest, then decodes the response body into res using the
+// Decoder for the Content-Type of the response. If res implements ResponseDecoder, then it will decode the response
+// body itself. A HTTPError is returned if the response has a non-2XX status code, and ErrResponseTooLarge is returned
+// if the response body is larger than the limit set by Binding.SetMaxResponseBytes.
+func (c *HTTPClient) Run(ctx context.Context, bindingName string, attrs map[string]any, req Request, res any) (err error) {
+	var response *http.Response
+	if response, err = c.do(ctx, bindingName, req); err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	var body []byte
+	if body, err = io.ReadAll(limitResponseBody(response.Body, attrs)); err != nil {
+		return errors.Wrap(err, "could not read response body")
+	}
+
+	// Responses without a body, such as 202 (Accepted) or 204 (No Content) responses, are not decoded
+	if len(body) == 0 {
+		return
+	}
+
+	// Binding.Execute passes a pointer to the interface holding the response wrapper, so we check the response wrapper
+	// itself for a ResponseDecoder implementation
+	target := res
+	if wrapper, ok := res.(*any); ok && wrapper != nil && *wrapper != nil {
+		target = *wrapper
+	}
+
+	if decoder, ok := target.(ResponseDecoder); ok {
+		if err = decoder.DecodeResponse(body); err != nil {
+			err = errors.Wrapf(err, "%T could not decode response body", target)
+		}
+		return
+	}
+
+	if err = c.decoderFor(response.Header.Get("Content-Type"))(body, target); err != nil {
+		err = errors.Wrapf(err, "could not decode response body into %T", target)
+	}
+	return
+}
+
+// MaxResponseBytesAttrKey is the key of the Attr that is added to a Binding by Binding.SetMaxResponseBytes. The value
+// of the Attr is the maximum number of bytes (int64) that can be read from the body of a response.
+const MaxResponseBytesAttrKey = "maxResponseBytes"
+
+// ErrResponseTooLarge is returned by HTTPClient when the body of a response is larger than the limit set by
+// Binding.SetMaxResponseBytes.
+var ErrResponseTooLarge = errors.New("response body is too large")
+
+// maxBytesReader reads from an io.LimitReader that is limited to one more byte than the maximum, so that
+// ErrResponseTooLarge can be returned when more than the maximum number of bytes are read.
+type maxBytesReader struct {
+	reader io.Reader
+	max    int64
+	read   int64
+}
+
+func (r *maxBytesReader) Read(p []byte) (n int, err error) {
+	n, err = r.reader.Read(p)
+	if r.read += int64(n); r.read > r.max {
+		return n - int(r.read-r.max), errors.Wrapf(ErrResponseTooLarge, "exceeded limit of %d bytes", r.max)
+	}
+	return
+}
+
+// limitResponseBody limits the given response body to the number of bytes within the Attr under the
+// MaxResponseBytesAttrKey. If there is no such Attr, then the response body is returned as is.
+func limitResponseBody(body io.Reader, attrs map[string]any) io.Reader {
+	max, ok := attrs[MaxResponseBytesAttrKey].(int64)
+	if !ok || max < 0 {
+		return body
+	}
+	return &maxBytesReader{reader: io.LimitReader(body, max+1), max: max}
+}
+
+// RunEach executes the given Request in the same way as Run, but streams the response body as a JSON array, calling
+// onElement for each element. This implements StreamArrayClient.
+func (c *HTTPClient) RunEach(ctx context.Context, bindingName string, attrs map[string]any, req Request, onElement func(decode func(v any) error) error) error {
+	response, err := c.do(ctx, bindingName, req)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	return decodeJSONArray(limitResponseBody(response.Body, attrs), onElement)
+}
+
+// LatestMeta returns the ResponseMeta of the latest response for the Binding of the given name.
+func (c *HTTPClient) LatestMeta(bindingName string) (ResponseMeta, bool) {
+	meta, ok := c.metas.Load(bindingName)
+	if !ok {
+		return ResponseMeta{}, false
+	}
+	return meta.(ResponseMeta), true
+}
+
+// LatestHeader returns the http.Header of the latest response for the Binding of the given name.
+func (c *HTTPClient) LatestHeader(bindingName string) http.Header {
+	meta, _ := c.LatestMeta(bindingName)
+	return meta.Header
+}
@@ -0,0 +1,348 @@
+package api
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestJSONDecoderUseNumber(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": 9007199254740993, "name": "big"}`))
+	}))
+	defer server.Close()
+
+	binding := NewRESTBinding[map[string]any, map[string]any](http.MethodGet, server.URL, nil, false)
+
+	// Without UseNumber the ID loses precision
+	response, err := binding.Execute(NewHTTPClient())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := response["id"].(float64); !ok {
+		t.Errorf("expected id to be decoded as a float64 by default, not %T", response["id"])
+	}
+
+	response, err = binding.Execute(NewHTTPClient().SetDecoder(JSONDecoderUseNumber))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id, ok := response["id"].(json.Number); !ok || id.String() != "9007199254740993" {
+		t.Errorf("expected id to be json.Number 9007199254740993, not %T %v", response["id"], response["id"])
+	}
+}
+
+func TestHTTPClient_Run(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "2")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte("slow down"))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient()
+	binding := NewRESTBinding[map[string]any, map[string]any](http.MethodGet, server.URL, nil, false).SetName("limited")
+	_, err := binding.Execute(client)
+
+	var retryAfterErr RetryAfterError
+	if !errors.As(err, &retryAfterErr) {
+		t.Fatalf("expected a RetryAfterError, got %v", err)
+	}
+	if retryAfterErr.RetryAfter() != 2*time.Second {
+		t.Errorf("expected RetryAfter to be 2s, not %s", retryAfterErr.RetryAfter())
+	}
+
+	if meta, ok := client.LatestMeta("limited"); !ok || meta.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected latest meta to have status code 429, not %+v (%t)", meta, ok)
+	}
+}
+
+// keyValues is a response type that decodes its own "key=value;key=value" wire format.
+type keyValues map[string]string
+
+func (kv *keyValues) DecodeResponse(body []byte) error {
+	*kv = make(keyValues)
+	for _, pair := range strings.Split(string(body), ";") {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return fmt.Errorf("pair %q is not of the form key=value", pair)
+		}
+		(*kv)[key] = value
+	}
+	return nil
+}
+
+func TestHTTPClient_ResponseDecoder(t *testing.T) {
+	for testNo, test := range []struct {
+		body        string
+		expected    keyValues
+		expectedErr bool
+	}{
+		{body: "id=1;name=andy", expected: keyValues{"id": "1", "name": "andy"}},
+		{body: "id", expectedErr: true},
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(test.body))
+		}))
+
+		binding := NewRESTBinding[keyValues, keyValues](http.MethodGet, server.URL, nil, false)
+		actual, err := binding.Execute(NewHTTPClient())
+		switch {
+		case test.expectedErr && err == nil:
+			t.Errorf("test no. %d expected an error", testNo+1)
+		case !test.expectedErr && err != nil:
+			t.Errorf("test no. %d returned an unexpected error: %v", testNo+1, err)
+		case !test.expectedErr && !reflect.DeepEqual(actual, test.expected):
+			t.Errorf("test no. %d expected %v, not %v", testNo+1, test.expected, actual)
+		}
+		server.Close()
+	}
+}
+
+func TestBindingProto_SetAccept(t *testing.T) {
+	type user struct {
+		ID   int    `json:"id" xml:"id"`
+		Name string `json:"name" xml:"name"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Accept") {
+		case "application/xml":
+			w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+			_, _ = w.Write([]byte(`<user><id>1</id><name>andy</name></user>`))
+		case "text/csv":
+			w.Header().Set("Content-Type", "text/csv")
+			_, _ = w.Write([]byte("1,andy"))
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id": 1, "name": "andy"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient().SetContentDecoder("text/csv", func(data []byte, v any) error {
+		id, name, _ := strings.Cut(string(data), ",")
+		u := v.(*user)
+		u.Name = name
+		_, err := fmt.Sscan(id, &u.ID)
+		return err
+	})
+
+	expected := user{ID: 1, Name: "andy"}
+	for testNo, accept := range []string{"", "application/xml", "text/csv"} {
+		binding := NewRESTBinding[user, user](http.MethodGet, server.URL, nil, false)
+		if accept != "" {
+			binding = binding.SetAccept(accept)
+		}
+
+		if actual, err := binding.Execute(client); err != nil {
+			t.Errorf("test no. %d could not execute Binding: %v", testNo+1, err)
+		} else if actual != expected {
+			t.Errorf("test no. %d expected %+v, not %+v", testNo+1, expected, actual)
+		}
+	}
+}
+
+func TestBindingProto_SetMaxResponseBytes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(strings.Repeat("a", 1024))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient()
+	binding := NewRESTBinding[string, string](http.MethodGet, server.URL, nil, false)
+	if _, err := binding.Execute(client); err != nil {
+		t.Errorf("expected no error without a limit, got %v", err)
+	}
+
+	if _, err := binding.SetMaxResponseBytes(2048).Execute(client); err != nil {
+		t.Errorf("expected no error for a response within the limit, got %v", err)
+	}
+
+	if _, err := binding.SetMaxResponseBytes(512).Execute(client); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("expected ErrResponseTooLarge for a response exceeding the limit, got %v", err)
+	}
+
+	// Streamed responses are also limited
+	arrayServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(make([]int, 1024))
+	}))
+	defer arrayServer.Close()
+
+	items := 0
+	arrayBinding := NewRESTBinding[int, int](http.MethodGet, arrayServer.URL, nil, false).SetMaxResponseBytes(512)
+	if err := arrayBinding.ExecuteEach(client, func(item int) error {
+		items++
+		return nil
+	}); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("expected ErrResponseTooLarge when streaming a response exceeding the limit, got %v", err)
+	} else if items > 256 {
+		t.Errorf("expected at most 256 items to be streamed before the limit was exceeded, not %d", items)
+	}
+}
+
+// recordingTransport is a http.RoundTripper that records the URL of each request before passing it on to
+// http.DefaultTransport.
+type recordingTransport struct {
+	mutex sync.Mutex
+	urls  []string
+}
+
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.mutex.Lock()
+	rt.urls = append(rt.urls, req.URL.String())
+	rt.mutex.Unlock()
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": 1}`))
+	}))
+	defer server.Close()
+
+	binding := NewRESTBinding[map[string]any, map[string]any](http.MethodGet, server.URL+"/users", nil, false)
+	for testNo, test := range []struct {
+		name string
+		api  func(transport http.RoundTripper) *API
+	}{
+		{
+			name: "nil Client",
+			api: func(transport http.RoundTripper) *API {
+				return NewAPI(nil, Schema{"users": WrapBinding(binding)}, WithHTTPClient(&http.Client{Transport: transport}))
+			},
+		},
+		{
+			name: "HTTPClient",
+			api: func(transport http.RoundTripper) *API {
+				return NewAPI(NewHTTPClient(), Schema{"users": WrapBinding(binding)}, WithHTTPClient(&http.Client{Transport: transport}))
+			},
+		},
+		{
+			name: "SetTransport",
+			api: func(transport http.RoundTripper) *API {
+				return NewAPI(NewHTTPClient().SetTransport(transport), Schema{"users": WrapBinding(binding)})
+			},
+		},
+	} {
+		transport := &recordingTransport{}
+		api := test.api(transport)
+		if _, err := api.Execute("users"); err != nil {
+			t.Errorf("test no. %d (%s) returned an unexpected error: %v", testNo+1, test.name, err)
+			continue
+		}
+
+		if expected := []string{server.URL + "/users"}; !reflect.DeepEqual(transport.urls, expected) {
+			t.Errorf("test no. %d (%s) expected the transport to record %v, not %v", testNo+1, test.name, expected, transport.urls)
+		}
+	}
+
+	if http.DefaultClient.Transport != nil {
+		t.Errorf("expected http.DefaultClient to not be modified by SetTransport")
+	}
+}
+
+func TestBindingProto_Exists(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		switch r.URL.Path {
+		case "/users/1":
+			w.WriteHeader(http.StatusOK)
+		case "/users/2":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	binding := NewRESTBinding[map[string]any, map[string]any](http.MethodGet, server.URL+"/users/{id}", func(binding Binding[map[string]any, map[string]any]) []BindingParam {
+		return Params("id", 0, true)
+	}, false)
+	for testNo, test := range []struct {
+		id          int
+		expected    bool
+		expectedErr bool
+	}{
+		{id: 1, expected: true},
+		{id: 2, expected: false},
+		{id: 3, expectedErr: true},
+	} {
+		exists, err := binding.Exists(NewHTTPClient(), test.id)
+		switch {
+		case test.expectedErr && err == nil:
+			t.Errorf("test no. %d expected an error", testNo+1)
+		case !test.expectedErr && err != nil:
+			t.Errorf("test no. %d returned an unexpected error: %v", testNo+1, err)
+		case exists != test.expected:
+			t.Errorf("test no. %d expected Exists to return %t, not %t", testNo+1, test.expected, exists)
+		}
+	}
+
+	if expected := []string{http.MethodHead, http.MethodHead, http.MethodHead}; !reflect.DeepEqual(methods, expected) {
+		t.Errorf("expected requests with methods %v, not %v", expected, methods)
+	}
+
+	post := NewRESTBinding[map[string]any, map[string]any](http.MethodPost, server.URL+"/users", nil, false)
+	if _, err := post.Exists(NewHTTPClient()); err == nil {
+		t.Errorf("expected an error when checking whether a POST Binding exists")
+	}
+}
+
+func TestBindingProto_SetAcceptEncoding(t *testing.T) {
+	type user struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	var encodings []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := []byte(`{"id": 1, "name": "andy"}`)
+		w.Header().Set("Content-Type", "application/json")
+		// The "/ignore" path mimics a server that ignores the Accept-Encoding header
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") || r.URL.Path == "/ignore" {
+			encodings = append(encodings, "identity")
+			_, _ = w.Write(body)
+			return
+		}
+
+		encodings = append(encodings, "gzip")
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		_, _ = gz.Write(body)
+		_ = gz.Close()
+	}))
+	defer server.Close()
+
+	expected := user{ID: 1, Name: "andy"}
+	for testNo, path := range []string{"/", "/ignore"} {
+		binding := NewRESTBinding[user, user](http.MethodGet, server.URL+path, nil, false).SetAcceptEncoding("gzip")
+		actual, meta, err := binding.ExecuteWithResponse(NewHTTPClient())
+		if err != nil {
+			t.Errorf("test no. %d could not execute Binding: %v", testNo+1, err)
+			continue
+		}
+
+		if actual != expected {
+			t.Errorf("test no. %d expected %+v, not %+v", testNo+1, expected, actual)
+		}
+
+		if encoding := meta.Header.Get("Content-Encoding"); encoding != "" {
+			t.Errorf("test no. %d expected the Content-Encoding header to be removed, not %q", testNo+1, encoding)
+		}
+	}
+
+	if expected := []string{"gzip", "identity"}; !reflect.DeepEqual(encodings, expected) {
+		t.Errorf("expected responses with encodings %v, not %v", expected, encodings)
+	}
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// jsonPointerTokens splits the given RFC 6901 JSON Pointer into its unescaped reference tokens. The empty pointer refers
+// to the whole document, and so returns no tokens.
+func jsonPointerTokens(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("JSON pointer %q must be empty or start with \"/\"", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// resolveJSONPointer returns the raw JSON value at the given RFC 6901 JSON Pointer within the given raw JSON document.
+func resolveJSONPointer(document json.RawMessage, pointer string) (json.RawMessage, error) {
+	tokens, err := jsonPointerTokens(pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	value := document
+	for tokenNo, token := range tokens {
+		location := "/" + strings.Join(tokens[:tokenNo], "/")
+		switch trimmed := bytes.TrimSpace(value); {
+		case bytes.HasPrefix(trimmed, []byte("{")):
+			var object map[string]json.RawMessage
+			if err = json.Unmarshal(value, &object); err != nil {
+				return nil, errors.Wrapf(err, "could not unmarshal object at %q", location)
+			}
+
+			var ok bool
+			if value, ok = object[token]; !ok {
+				return nil, fmt.Errorf("object at %q does not contain key %q", location, token)
+			}
+		case bytes.HasPrefix(trimmed, []byte("[")):
+			var array []json.RawMessage
+			if err = json.Unmarshal(value, &array); err != nil {
+				return nil, errors.Wrapf(err, "could not unmarshal array at %q", location)
+			}
+
+			var index int
+			if index, err = strconv.Atoi(token); err != nil || index < 0 || index >= len(array) {
+				return nil, fmt.Errorf("%q is not a valid index for array of length %d at %q", token, len(array), location)
+			}
+			value = array[index]
+		default:
+			return nil, fmt.Errorf("cannot reference %q within value at %q as it is not an object or array", token, location)
+		}
+	}
+	return value, nil
+}
+
+// UnwrapJSONPointer returns a BindingResponseWrapperMethod and a BindingResponseUnwrappedMethod that extract ResT from
+// the value at the given RFC 6901 JSON Pointer within the response. The response is first captured as raw JSON, then
+// the value at the pointer is unmarshalled into ResT. For example, "/data/items" will extract the "items" array from
+// the response:
+//
+//	{"data": {"items": [...]}}
+//
+// The returned methods can be passed to NewBinding, or set using Binding.SetResponseWrapperMethod and
+// Binding.SetResponseUnwrappedMethod. The Client must unmarshal the response as JSON into the response wrapper.
+func UnwrapJSONPointer[ResT any, RetT any](pointer string) (wrap BindingResponseWrapperMethod[ResT, RetT], unwrap BindingResponseUnwrappedMethod[ResT, RetT]) {
+	wrap = func(binding Binding[ResT, RetT], args ...any) (responseWrapper reflect.Value, err error) {
+		return reflect.ValueOf(new(json.RawMessage)), nil
+	}
+
+	unwrap = func(binding Binding[ResT, RetT], responseWrapper reflect.Value, args ...any) (response ResT, err error) {
+		raw, ok := responseWrapper.Interface().(*json.RawMessage)
+		if !ok {
+			err = fmt.Errorf("response wrapper is of type %s and not %T", responseWrapper.Type(), raw)
+			return
+		}
+
+		var value json.RawMessage
+		if value, err = resolveJSONPointer(*raw, pointer); err != nil {
+			err = errors.Wrapf(err, "could not resolve JSON pointer %q", pointer)
+			return
+		}
+
+		if err = json.Unmarshal(value, &response); err != nil {
+			err = errors.Wrapf(err, "could not unmarshal value at JSON pointer %q into %T", pointer, response)
+		}
+		return
+	}
+	return
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestUnwrapJSONPointer(t *testing.T) {
+	type item struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		return map[string]any{
+			"data": map[string]any{
+				"items": []item{{1, "one"}, {2, "two"}},
+				"a/b":   map[string]any{"~c": "escaped"},
+			},
+		}, nil
+	}}
+
+	for testNo, test := range []struct {
+		execute     func() (any, error)
+		expected    any
+		expectedErr bool
+	}{
+		{
+			execute: func() (any, error) {
+				wrap, unwrap := UnwrapJSONPointer[[]item, []item]("/data/items")
+				return NewBinding(mockRequestMethod[[]item, []item], wrap, unwrap, nil, nil, false).Execute(client)
+			},
+			expected: []item{{1, "one"}, {2, "two"}},
+		},
+		{
+			execute: func() (any, error) {
+				wrap, unwrap := UnwrapJSONPointer[string, string]("/data/items/1/name")
+				return NewBinding(mockRequestMethod[string, string], wrap, unwrap, nil, nil, false).Execute(client)
+			},
+			expected: "two",
+		},
+		{
+			execute: func() (any, error) {
+				wrap, unwrap := UnwrapJSONPointer[string, string]("/data/a~1b/~0c")
+				return NewBinding(mockRequestMethod[string, string], wrap, unwrap, nil, nil, false).Execute(client)
+			},
+			expected: "escaped",
+		},
+		{
+			execute: func() (any, error) {
+				wrap, unwrap := UnwrapJSONPointer[[]item, []item]("/data/missing")
+				return NewBinding(mockRequestMethod[[]item, []item], wrap, unwrap, nil, nil, false).Execute(client)
+			},
+			expectedErr: true,
+		},
+		{
+			execute: func() (any, error) {
+				wrap, unwrap := UnwrapJSONPointer[item, item]("/data/items/2")
+				return NewBinding(mockRequestMethod[item, item], wrap, unwrap, nil, nil, false).Execute(client)
+			},
+			expectedErr: true,
+		},
+	} {
+		actual, err := test.execute()
+		switch {
+		case test.expectedErr && err == nil:
+			t.Errorf("test no. %d expected an error, got %v", testNo+1, actual)
+		case !test.expectedErr && err != nil:
+			t.Errorf("test no. %d returned an unexpected error: %v", testNo+1, err)
+		case !test.expectedErr && !reflect.DeepEqual(actual, test.expected):
+			t.Errorf("test no. %d expected %v, not %v", testNo+1, test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"reflect"
+)
+
+// ArgsKey returns a deterministic key for executing the Binding of the given name with the given arguments. This can be
+// used as the key for caching responses or deduplicating executions. Each argument is canonicalised by encoding it as
+// JSON along with its type, so the keys of map arguments are always in sorted order, and arguments of different types
+// that have the same JSON encoding (such as 1 and "1") produce different keys. An error is returned if an argument
+// cannot be encoded as JSON, or if it contains a struct with fields that would be left out of its JSON encoding
+// (unexported fields, or fields tagged with `json:"-"`), as different values of such a struct could produce the same
+// key. Types that implement json.Marshaler or encoding.TextMarshaler, such as time.Time, are trusted to encode all of
+// their state.
+func ArgsKey(name string, args []any) (string, error) {
+	type typedArg struct {
+		Type  string `json:"t"`
+		Value any    `json:"v"`
+	}
+
+	typedArgs := make([]typedArg, len(args))
+	for i, arg := range args {
+		if err := checkKeyable(reflect.ValueOf(arg)); err != nil {
+			return "", errors.Wrapf(err, "could not canonicalise argument no. %d for Binding %q", i, name)
+		}
+		typedArgs[i] = typedArg{Type: fmt.Sprintf("%T", arg), Value: arg}
+	}
+
+	data, err := json.Marshal(typedArgs)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not canonicalise arguments for Binding %q", name)
+	}
+
+	sum := sha256.Sum256(data)
+	return name + ":" + hex.EncodeToString(sum[:]), nil
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// checkKeyable returns an error if the given value contains a struct with fields that would be left out of its JSON
+// encoding, which means that ArgsKey cannot tell different values of the struct apart.
+func checkKeyable(val reflect.Value) error {
+	if !val.IsValid() {
+		return nil
+	}
+
+	for _, t := range []reflect.Type{val.Type(), reflect.PointerTo(val.Type())} {
+		if t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) {
+			return nil
+		}
+	}
+
+	switch val.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if val.IsNil() {
+			return nil
+		}
+		return checkKeyable(val.Elem())
+	case reflect.Struct:
+		for i := 0; i < val.NumField(); i++ {
+			field := val.Type().Field(i)
+			switch {
+			case !field.IsExported() && !field.Anonymous:
+				return fmt.Errorf("%s has unexported field %q that is left out of its JSON encoding", val.Type(), field.Name)
+			case field.Tag.Get("json") == "-":
+				return fmt.Errorf("%s has field %q that is left out of its JSON encoding", val.Type(), field.Name)
+			}
+
+			if err := checkKeyable(val.Field(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			if err := checkKeyable(val.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := val.MapRange()
+		for iter.Next() {
+			if err := checkKeyable(iter.Value()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
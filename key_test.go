@@ -0,0 +1,112 @@
+package api
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestArgsKey(t *testing.T) {
+	// Maps with many keys are iterated in a different order each time, so we build the same map in different orders
+	a, b := make(map[string]any), make(map[string]any)
+	for i := 0; i < 100; i++ {
+		a[string(rune('a'+i%26))+string(rune('a'+i/26))] = i
+		b[string(rune('a'+(99-i)%26))+string(rune('a'+(99-i)/26))] = 99 - i
+	}
+
+	keyA, err := ArgsKey("boards", []any{1, a})
+	if err != nil {
+		t.Fatalf("could not create key: %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		if keyB, err := ArgsKey("boards", []any{1, b}); err != nil {
+			t.Fatalf("could not create key: %v", err)
+		} else if keyA != keyB {
+			t.Errorf("expected keys for the same map arguments to be equal: %q != %q", keyA, keyB)
+		}
+	}
+
+	for testNo, test := range []struct {
+		name string
+		args []any
+	}{
+		{"items", []any{1, a}},
+		{"boards", []any{"1", a}},
+		{"boards", []any{1.5, a}},
+		{"boards", []any{1}},
+	} {
+		if key, err := ArgsKey(test.name, test.args); err != nil {
+			t.Errorf("test no. %d could not create key: %v", testNo+1, err)
+		} else if key == keyA {
+			t.Errorf("test no. %d expected key for %s%v to differ from %q", testNo+1, test.name, test.args, keyA)
+		}
+	}
+
+	if _, err = ArgsKey("boards", []any{func() {}}); err == nil {
+		t.Errorf("expected an error for an argument that cannot be encoded")
+	}
+}
+
+func TestArgsKey_UnexportedFields(t *testing.T) {
+	type filter struct {
+		query string
+	}
+
+	type tagged struct {
+		Query  string `json:"query"`
+		Secret string `json:"-"`
+	}
+
+	for testNo, args := range [][]any{
+		{filter{query: "a"}},
+		{&filter{query: "b"}},
+		{[]any{1, filter{query: "c"}}},
+		{map[string]any{"filter": filter{query: "d"}}},
+		{tagged{Query: "e", Secret: "f"}},
+	} {
+		if key, err := ArgsKey("search", args); err == nil {
+			t.Errorf("test no. %d expected an error for %v, not the key %q", testNo+1, args, key)
+		}
+	}
+
+	// Two executions with distinct struct arguments must never be deduplicated with one another
+	var (
+		mutex   sync.Mutex
+		queries []string
+		release = make(chan struct{})
+	)
+	client := &concurrentClient{run: func(bindingName string, args []any) (any, error) {
+		mutex.Lock()
+		queries = append(queries, args[0].(filter).query)
+		mutex.Unlock()
+		<-release
+		return args[0].(filter).query, nil
+	}}
+	binding := NewBindingChain(mockRequestMethod[string, string]).SetParamsMethod(func(binding Binding[string, string]) []BindingParam {
+		return Params("filter", filter{}, true)
+	}).SetSingleFlight(true)
+
+	var wg sync.WaitGroup
+	results := make([]string, 2)
+	for i, query := range []string{"a", "b"} {
+		wg.Add(1)
+		go func(i int, query string) {
+			defer wg.Done()
+			results[i], _ = binding.Execute(client, filter{query: query})
+		}(i, query)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if expected := []string{"a", "b"}; !reflect.DeepEqual(results, expected) {
+		t.Errorf("expected results %v, not %v", expected, results)
+	}
+
+	if len(queries) != 2 {
+		t.Errorf("expected 2 requests, not %d: %v", len(queries), queries)
+	}
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"github.com/pkg/errors"
+)
+
+// MultiPage is a page that was fetched by a MultiPaginator, along with the name of the Binding that it was fetched
+// for.
+type MultiPage[RetT any] struct {
+	// Binding is the name that the Paginator that fetched the Page was added to the MultiPaginator with.
+	Binding string
+	// Page is the page that was fetched.
+	Page RetT
+}
+
+// MultiPaginator fetches pages from multiple Paginator(s) by calling Paginator.Next for each Paginator in turn
+// (round-robin), so that the pages of multiple paginated Binding(s) are interleaved. This is useful for merging the
+// feeds of several Binding(s). Each Paginator respects the rate limits and wait time of its own Binding. Use
+// NewMultiPaginator to create a new MultiPaginator and MultiPaginator.Add to add Paginator(s) to it. To interleave
+// Binding(s) with different return types, use un-typed Paginator(s) created using NewPaginator.
+type MultiPaginator[ResT any, RetT any] struct {
+	names      []string
+	paginators []Paginator[ResT, RetT]
+	next       int
+	page       MultiPage[RetT]
+}
+
+// NewMultiPaginator creates a new MultiPaginator with no Paginator(s).
+func NewMultiPaginator[ResT any, RetT any]() *MultiPaginator[ResT, RetT] {
+	return &MultiPaginator[ResT, RetT]{
+		names:      make([]string, 0),
+		paginators: make([]Paginator[ResT, RetT], 0),
+	}
+}
+
+// Add adds the given Paginator to the MultiPaginator under the given name. Paginator(s) are fetched from in the order
+// that they were added. This returns the MultiPaginator so it can be chained.
+func (mp *MultiPaginator[ResT, RetT]) Add(name string, paginator Paginator[ResT, RetT]) *MultiPaginator[ResT, RetT] {
+	mp.names = append(mp.names, name)
+	mp.paginators = append(mp.paginators, paginator)
+	return mp
+}
+
+// Continue returns whether any of the Paginator(s) within the MultiPaginator can continue fetching more pages.
+func (mp *MultiPaginator[ResT, RetT]) Continue() bool {
+	for _, paginator := range mp.paginators {
+		if paginator.Continue() {
+			return true
+		}
+	}
+	return false
+}
+
+// Next fetches the next page from the next Paginator (in round-robin order) that can continue fetching pages. The
+// fetched page can be retrieved using Page. Empty pages, such as the empty page that is fetched to find the end of some
+// Paginator(s), are skipped, so Next carries on fetching until a non-empty page is found. If all Paginator(s) are
+// exhausted without fetching a non-empty page then Page will return an empty MultiPage.
+func (mp *MultiPaginator[ResT, RetT]) Next() (err error) {
+	_, err = mp.nextPage()
+	return
+}
+
+// nextPage implements Next, and also returns whether a non-empty page was fetched.
+func (mp *MultiPaginator[ResT, RetT]) nextPage() (fetched bool, err error) {
+	mp.page = MultiPage[RetT]{}
+	for mp.Continue() {
+		for i := 0; i < len(mp.paginators); i++ {
+			idx := (mp.next + i) % len(mp.paginators)
+			paginator := mp.paginators[idx]
+			if !paginator.Continue() {
+				continue
+			}
+
+			mp.next = (idx + 1) % len(mp.paginators)
+			if err = paginator.Next(); err != nil {
+				return false, errors.Wrapf(err, "could not fetch next page for %q", mp.names[idx])
+			}
+
+			if multiPageEmpty(paginator) {
+				break
+			}
+			mp.page = MultiPage[RetT]{Binding: mp.names[idx], Page: paginator.Page()}
+			return true, nil
+		}
+	}
+	return
+}
+
+// multiPageEmpty returns whether the current page of the given Paginator is empty. This is the case if the page has a
+// length of 0, or if the function set by Paginator.SetEmptyDetector reports that it is empty.
+func multiPageEmpty[ResT any, RetT any](paginator Paginator[ResT, RetT]) bool {
+	if detector, ok := paginator.(interface{ emptyPage() bool }); ok && detector.emptyPage() {
+		return true
+	}
+	length, ok := pageLen(paginator.Page())
+	return ok && length == 0
+}
+
+// Page returns the page that was fetched by the latest call to Next, along with the name of its Binding.
+func (mp *MultiPaginator[ResT, RetT]) Page() MultiPage[RetT] { return mp.page }
+
+// All fetches pages from all Paginator(s) until they are all exhausted, and returns the non-empty pages in the order
+// that they were fetched. If an error occurs, then the pages that were fetched before the error are returned along
+// with the error.
+func (mp *MultiPaginator[ResT, RetT]) All() (pages []MultiPage[RetT], err error) {
+	pages = make([]MultiPage[RetT], 0)
+	for mp.Continue() {
+		var fetched bool
+		if fetched, err = mp.nextPage(); err != nil {
+			return
+		}
+
+		if fetched {
+			pages = append(pages, mp.Page())
+		}
+	}
+	return
+}
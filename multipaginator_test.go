@@ -0,0 +1,33 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMultiPaginator(t *testing.T) {
+	long, err := NewTypedPaginator(cappedPageClient(5, 2), 0, pagedIntBinding(), 2)
+	if err != nil {
+		t.Fatalf("could not create long Paginator: %v", err)
+	}
+
+	short, err := NewTypedPaginator(cappedPageClient(2, 2), 0, pagedIntBinding(), 2)
+	if err != nil {
+		t.Fatalf("could not create short Paginator: %v", err)
+	}
+
+	pages, err := NewMultiPaginator[[]int, []int]().Add("long", long).Add("short", short).All()
+	if err != nil {
+		t.Fatalf("could not fetch all pages: %v", err)
+	}
+
+	expected := []MultiPage[[]int]{
+		{"long", []int{0, 1}},
+		{"short", []int{0, 1}},
+		{"long", []int{2, 3}},
+		{"long", []int{4}},
+	}
+	if !reflect.DeepEqual(pages, expected) {
+		t.Errorf("expected pages %v, not %v", expected, pages)
+	}
+}
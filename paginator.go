@@ -1,10 +1,14 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/andygello555/gotils/v2/slices"
 	mapset "github.com/deckarep/golang-set/v2"
 	"github.com/pkg/errors"
+	"io"
+	"net/http"
 	"reflect"
 	"strings"
 	"time"
@@ -31,15 +35,123 @@ type Lenable interface {
 	Len() int
 }
 
+// Itemable is an interface that provides access to the items within a page. Mergeable return types must implement
+// Itemable to be used with Paginator.UntilFound.
+type Itemable interface {
+	// Items returns the items within the page.
+	Items() []any
+}
+
+// Paged can be implemented by the response type (ResT) of a paginated Binding, when the response includes pagination
+// metadata such as the total number of pages and the current page number. When the response wrapper of the last page
+// implements Paged, Paginator.Continue uses it to know exactly when to stop, rather than fetching an empty page.
+type Paged interface {
+	// TotalPages returns the total number of pages.
+	TotalPages() int
+	// CurrentPage returns the page number of the response, starting from 1.
+	CurrentPage() int
+}
+
+// pagedWrapper returns the Paged implementation of the given response wrapper. The response wrapper can either be a
+// ResT or a pointer to a ResT, and Paged can be implemented using either a value or pointer receiver.
+func pagedWrapper(wrapper reflect.Value) (Paged, bool) {
+	if !wrapper.IsValid() || (wrapper.Kind() == reflect.Pointer && wrapper.IsNil()) {
+		return nil, false
+	}
+
+	if paged, ok := wrapper.Interface().(Paged); ok {
+		return paged, true
+	}
+
+	if wrapper.Kind() == reflect.Pointer {
+		paged, ok := wrapper.Elem().Interface().(Paged)
+		return paged, ok
+	}
+
+	ptr := reflect.New(wrapper.Type())
+	ptr.Elem().Set(wrapper)
+	paged, ok := ptr.Interface().(Paged)
+	return paged, ok
+}
+
+// LinkParser parses the URL of the next page from the http.Header of a response. The second return value should be
+// false if there is no next page.
+type LinkParser func(header http.Header) (next string, ok bool)
+
+// ParseLinkHeader parses the URL of the next page from the Link header within the given http.Header. I.e. the URL of
+// the link with the "next" relation type:
+//
+//	Link: <https://api.example.com/items?page=2>; rel="next", <https://api.example.com/items?page=5>; rel="last"
+//
+// ParseLinkHeader implements LinkParser, and is the default LinkParser used by LinkHeaderPagination.
+func ParseLinkHeader(header http.Header) (next string, ok bool) {
+	for _, value := range header.Values("Link") {
+		for _, link := range strings.Split(value, ",") {
+			segments := strings.Split(link, ";")
+			target := strings.TrimSpace(segments[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+
+			for _, segment := range segments[1:] {
+				key, val, found := strings.Cut(strings.TrimSpace(segment), "=")
+				if !found || strings.TrimSpace(key) != "rel" {
+					continue
+				}
+
+				for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(val), `"`)) {
+					if strings.EqualFold(rel, "next") {
+						return strings.TrimSuffix(strings.TrimPrefix(target, "<"), ">"), true
+					}
+				}
+			}
+		}
+	}
+	return "", false
+}
+
 type paginatorParamSet int
 
 const (
 	unknownParamSet paginatorParamSet = iota
 	pageParamSet
 	afterParamSet
+	// linkHeaderParamSet does not require any params, as the URL of the next page is taken from the Link header of the
+	// previous response. It cannot be detected from the params of a Binding, so it must be set using the
+	// LinkHeaderPagination PaginatorOption.
+	linkHeaderParamSet
+	// tokenParamSet uses the "pageToken" param, whose value for the next page is an opaque token that is extracted from
+	// the previous page. It cannot be detected from the params of a Binding, as it requires a function to extract the
+	// token, so it must be set using the PageTokenPagination PaginatorOption.
+	tokenParamSet
+	// pageInfoParamSet uses the "after" param, whose value for the next page is the end cursor that is extracted from the
+	// "pageInfo" of the previous page, as in the GraphQL Relay connection pattern. Unlike afterParamSet, the page does
+	// not need to be Afterable, and whether there is a next page is also extracted from the page. It must be set using
+	// the PageInfoPagination PaginatorOption.
+	pageInfoParamSet
 )
 
+// tokenParam returns the name of the param that is given the token extracted from the previous page, for the
+// paginatorParamSet(s) that extract tokens from each page (i.e. tokenParamSet and pageInfoParamSet).
+func (pps paginatorParamSet) tokenParam() (string, bool) {
+	switch pps {
+	case tokenParamSet:
+		return "pageToken", true
+	case pageInfoParamSet:
+		return "after", true
+	default:
+		return "", false
+	}
+}
+
 func (pps paginatorParamSet) String() string {
+	switch pps {
+	case linkHeaderParamSet:
+		return "{link}"
+	case pageInfoParamSet:
+		// pageInfoParamSet uses the same params as afterParamSet, so it is named after its PaginatorOption instead
+		return "{pageInfo}"
+	}
 	return strings.TrimPrefix(pps.Set().String(), "Set")
 }
 
@@ -47,6 +159,8 @@ func (pps paginatorParamSet) GetPaginatorParamValue(params []BindingParam, resou
 	switch pps {
 	case pageParamSet:
 		return map[string]any{"page": page}, nil
+	case linkHeaderParamSet:
+		return map[string]any{}, nil
 	case afterParamSet:
 		if resource == nil {
 			for _, param := range params {
@@ -68,6 +182,10 @@ func (pps paginatorParamSet) GetPaginatorParamValue(params []BindingParam, resou
 }
 
 func (pps paginatorParamSet) InsertPaginatorParamValues(params []BindingParam, args []any, paginatorValues map[string]any) ([]any, error) {
+	if len(paginatorValues) == 0 {
+		return args, nil
+	}
+
 	ppsSet := pps.Set()
 	ppsSetUsed := mapset.NewSet[string]()
 
@@ -101,6 +219,10 @@ func (pps paginatorParamSet) Set() mapset.Set[string] {
 		return mapset.NewSet("page")
 	case afterParamSet:
 		return mapset.NewSet("after")
+	case tokenParamSet:
+		return mapset.NewSet("pageToken")
+	case pageInfoParamSet:
+		return mapset.NewSet("after")
 	default:
 		return mapset.NewSet[string]()
 	}
@@ -110,11 +232,18 @@ func (pps paginatorParamSet) Sets() []paginatorParamSet {
 	return []paginatorParamSet{pageParamSet, afterParamSet}
 }
 
-func checkPaginatorParams(params []BindingParam) paginatorParamSet {
+// checkPaginatorParams returns the first of the given paginatorParamSet(s) whose params are all within the given
+// BindingParam(s). If no paginatorParamSet(s) are given, then the paginatorParamSet(s) that can be detected from the
+// params of a Binding are checked. unknownParamSet is returned if none match.
+func checkPaginatorParams(params []BindingParam, sets ...paginatorParamSet) paginatorParamSet {
 	paramNameSet := mapset.NewSet(slices.Comprehension(params, func(idx int, value BindingParam, arr []BindingParam) string {
 		return value.name
 	})...)
-	for _, pps := range unknownParamSet.Sets() {
+	if len(sets) == 0 {
+		sets = unknownParamSet.Sets()
+	}
+
+	for _, pps := range sets {
 		if pps.Set().Difference(paramNameSet).Cardinality() == 0 {
 			return pps
 		}
@@ -122,31 +251,301 @@ func checkPaginatorParams(params []BindingParam) paginatorParamSet {
 	return unknownParamSet
 }
 
+// PaginatorParamConfig explicitly maps the params that a Paginator paginates using onto the BindingParam(s) of a
+// Binding, which allows a Binding with oddly-named pagination params to be paginated. Each field is the name of the
+// BindingParam that carries that value, and is ignored if it is empty. A PaginatorParamConfig can be set using
+// Binding.SetPaginatorParamSet.
+type PaginatorParamConfig struct {
+	// Page is the name of the BindingParam that carries the page number, instead of "page".
+	Page string
+	// After is the name of the BindingParam that carries the cursor of the next page, instead of "after".
+	After string
+	// PageToken is the name of the BindingParam that carries the token of the next page when the PageTokenPagination
+	// PaginatorOption is used, instead of "pageToken".
+	PageToken string
+}
+
+// aliases returns the names of the BindingParam(s) given in the PaginatorParamConfig along with the names of the
+// paginator params that they carry. The order is fixed so that any errors are deterministic.
+func (ppc PaginatorParamConfig) aliases() [][2]string {
+	aliases := make([][2]string, 0, 3)
+	for _, alias := range [][2]string{{ppc.Page, "page"}, {ppc.After, "after"}, {ppc.PageToken, "pageToken"}} {
+		if alias[0] != "" {
+			aliases = append(aliases, alias)
+		}
+	}
+	return aliases
+}
+
+// sets returns the paginatorParamSet(s) that should be checked by checkPaginatorParams when detecting the
+// paginatorParamSet of a Binding with this PaginatorParamConfig. Only the paginatorParamSet(s) whose params have been
+// explicitly mapped are returned, which overrides the auto-detection of the other paginatorParamSet(s). If none have
+// been mapped, then nil is returned so that every detectable paginatorParamSet is checked.
+func (ppc PaginatorParamConfig) sets() (sets []paginatorParamSet) {
+	for _, pps := range unknownParamSet.Sets() {
+		if (pps == pageParamSet && ppc.Page != "") || (pps == afterParamSet && ppc.After != "") {
+			sets = append(sets, pps)
+		}
+	}
+	return
+}
+
+// rename returns a copy of the given BindingParam(s) where each BindingParam that is mapped by the PaginatorParamConfig
+// is renamed to the name of the paginator param that it carries. This means that the Paginator can detect, and insert
+// the values of, the paginator params by their usual names. An error is returned if a mapped BindingParam does not
+// exist, if a BindingParam is mapped more than once, or if another BindingParam already has the usual name.
+func (ppc PaginatorParamConfig) rename(params []BindingParam) ([]BindingParam, error) {
+	aliases := ppc.aliases()
+	mapped := mapset.NewSet[string]()
+	for _, alias := range aliases {
+		mapped.Add(alias[0])
+	}
+
+	renamed := make([]BindingParam, len(params))
+	copy(renamed, params)
+	for _, alias := range aliases {
+		name, paginatorParam := alias[0], alias[1]
+		found := false
+		for i, param := range params {
+			switch {
+			case param.name == name:
+				if renamed[i].name != name {
+					return nil, fmt.Errorf("param %q cannot carry both the %q and %q paginator params", name, renamed[i].name, paginatorParam)
+				}
+				renamed[i].name, found = paginatorParam, true
+			case param.name == paginatorParam && !mapped.Contains(param.name):
+				return nil, fmt.Errorf(
+					"cannot use param %q as the %q paginator param, as there is already a param named %q",
+					name, paginatorParam, paginatorParam,
+				)
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("cannot find param %q to use as the %q paginator param", name, paginatorParam)
+		}
+	}
+	return renamed, nil
+}
+
 var limitParamNames = mapset.NewSet[string]("limit", "count")
 
+// LimitExtractor finds the number of resources requested by each page from the given BindingParam(s) and arguments
+// for a Binding. This is used by Paginator(s) to check whether a ResourceRateLimit has enough resources remaining for
+// the next page. The second return value should be false if the number of resources cannot be found.
+type LimitExtractor func(params []BindingParam, args []any) (float64, bool)
+
+// limitParamExtractor returns a LimitExtractor that finds the argument for the first numeric BindingParam that is
+// named one of the given names.
+func limitParamExtractor(names mapset.Set[string]) LimitExtractor {
+	return func(params []BindingParam, args []any) (float64, bool) {
+		for i, param := range params {
+			if !names.Contains(param.name) {
+				continue
+			}
+
+			var argVal reflect.Value
+			if i < len(args) {
+				argVal = reflect.ValueOf(args[i])
+			} else if !param.required && !param.variadic {
+				argVal = reflect.ValueOf(param.defaultValue)
+			}
+
+			switch {
+			case argVal.CanInt():
+				return float64(argVal.Int()), true
+			case argVal.CanUint():
+				return float64(argVal.Uint()), true
+			case argVal.CanFloat():
+				return argVal.Float(), true
+			}
+		}
+		return 0, false
+	}
+}
+
+// defaultLimitExtractor is the LimitExtractor that is used when no LimitExtractor has been set using
+// Paginator.SetLimitExtractor or the LimitParamNames PaginatorOption. It finds the argument for the first numeric
+// BindingParam that is named one of the limitParamNames.
+var defaultLimitExtractor = limitParamExtractor(limitParamNames)
+
+// ErrTimeBudgetExceeded is returned by Paginator.AllWithin, along with the pages fetched so far, when the time budget
+// is spent before all pages have been fetched.
+var ErrTimeBudgetExceeded = errors.New("paginator time budget exceeded")
+
+// StopReason is the reason why Paginator.UntilReason stopped fetching pages.
+type StopReason int
+
+const (
+	// StopExhausted means that there were no more pages to fetch.
+	StopExhausted StopReason = iota
+	// StopPredicate means that the predicate given to Paginator.UntilReason returned false.
+	StopPredicate
+	// StopError means that an error occurred whilst fetching or merging a page.
+	StopError
+)
+
+func (sr StopReason) String() string {
+	switch sr {
+	case StopExhausted:
+		return "exhausted"
+	case StopPredicate:
+		return "predicate"
+	case StopError:
+		return "error"
+	default:
+		return fmt.Sprintf("StopReason(%d)", int(sr))
+	}
+}
+
 // Paginator can fetch resources from a Binding that is paginated. Use NewPaginator or NewTypedPaginator to create a new
 // one for a given Binding.
 type Paginator[ResT any, RetT any] interface {
 	// Continue returns whether the Paginator can continue fetching more pages for the Binding. This will also return true
-	// when the Paginator is on the first page.
+	// when the Paginator is on the first page. If the response wrapper of the last page implements Paged, then it is used
+	// to find whether there are more pages.
 	Continue() bool
 	// Page fetches the current page of results.
 	Page() RetT
-	// Next fetches the next page from the Binding. The result can be fetched using the Page method.
+	// LastWrapper returns the response wrapper (see Binding.ResponseWrapper) that the current page was unmarshalled into.
+	// This allows per-page metadata within the response wrapper, such as total counts or next page tokens, to be read.
+	// The returned reflect.Value is invalid if no page has been fetched yet.
+	LastWrapper() reflect.Value
+	// SetPageTransform sets a function that transforms each page before it is merged into the aggregation of pages
+	// returned by All, AllReversed, Pages, Until, and UntilOlderThan. If the transform returns an error, then fetching
+	// pages is aborted and the error is returned. Page and Channel return pages that have not been transformed. This
+	// returns the Paginator so it can be chained.
+	SetPageTransform(transform func(page RetT) (RetT, error)) Paginator[ResT, RetT]
+	// SetEmptyDetector sets a function that decides whether a page is empty, for APIs that signal the end of the pages
+	// in other ways than an empty page, such as a page padded with nulls or a page containing a sentinel item. When the
+	// detector returns true for the current page, Continue returns false, and the page is not merged into the
+	// aggregation of pages returned by All, Pages, Until, etc. This returns the Paginator so it can be chained.
+	SetEmptyDetector(detector func(page RetT) bool) Paginator[ResT, RetT]
+	// SetMerger sets a function that replaces the default merging of each page into the aggregation of pages, which
+	// appends slices or calls Mergeable.Merge. The merger is called with the current aggregate, which is nil for the
+	// first page, and the (transformed) page, and should return the new aggregate. This allows pages to be aggregated
+	// into any type, such as a map keyed by ID. If the aggregate is not of type RetT, then All, Pages, Until,
+	// UntilReason, and UntilFound will return the zero value of RetT, and the aggregate can be fetched using Aggregate.
+	// This returns the Paginator so it can be chained.
+	SetMerger(merger func(aggregate any, page any) (any, error)) Paginator[ResT, RetT]
+	// Aggregate returns the latest aggregation of pages that was returned by All, Pages, Until, UntilReason, or
+	// UntilFound. This is useful for fetching the aggregate of a merger set by SetMerger that is not of type RetT.
+	Aggregate() any
+	// SetLimitExtractor sets the LimitExtractor that is used to find the number of resources requested by each page,
+	// when the Client is a RateLimitedClient that returns ResourceRateLimit(s). By default, the argument for the
+	// "limit" or "count" BindingParam is used. This is useful when the page size is nested within another argument.
+	// This returns the Paginator so it can be chained.
+	SetLimitExtractor(extractor LimitExtractor) Paginator[ResT, RetT]
+	// ParamSet returns the name of the set of params that the Paginator uses to paginate. This is "page" or "after",
+	// depending on the BindingParam(s) of the Binding, "link" if the LinkHeaderPagination PaginatorOption was used,
+	// "pageToken" if the PageTokenPagination PaginatorOption was used, or "pageInfo" if the PageInfoPagination
+	// PaginatorOption was used.
+	ParamSet() string
+	// RequestLog returns the arguments that were passed to the Binding for each page that has been fetched, in the order
+	// that the pages were fetched. Requests are only logged when the RecordRequests PaginatorOption is given, otherwise
+	// RequestLog returns nil.
+	RequestLog() [][]any
+	// State returns the JSON-serialised position of the Paginator. This includes the next page number, the next "after"
+	// cursor (which must be JSON-serialisable), the next Link header URL, and the number of items fetched so far. The
+	// state can be passed to NewPaginatorFromState to resume pagination later, even within a new process. A state taken
+	// after a page failed to be fetched resumes from the page that failed.
+	State() ([]byte, error)
+	// Requests returns the number of times that the Binding has been executed by the Paginator. This can be greater than
+	// the number of pages that have been fetched, as a page is executed again when the first request is ignored due to
+	// there being no RateLimit, or when a page is retried after ClearErr. Retries made by the RetryPolicy of the Binding
+	// are not counted, as they happen within a single execution.
+	Requests() int
+	// PageSize returns the effective page size of the Paginator. This is the length of the first page that was fetched,
+	// which might be less than the page size that was requested if the API caps the page size server-side. PageSize
+	// will return 0 if the first page has not yet been fetched, or if the length of a page cannot be found.
+	PageSize() int
+	// Next fetches the next page from the Binding. The result can be fetched using the Page method. If fetching the
+	// page fails, then the error is sticky: it is returned by Err, Continue will return false, and subsequent calls to
+	// Next will return the same error without fetching, until ClearErr is called.
 	Next() error
+	// Err returns the sticky error returned by the last failed call to Next, or nil if there is none.
+	Err() error
+	// ClearErr resets the sticky error returned by Err, so that Continue and Next can proceed from the current page. The
+	// current page, and the page number of the next page to fetch, are left untouched. This allows a caller to retry
+	// after a transient error without constructing a new Paginator.
+	ClearErr()
+	// Peek fetches the next page from the Binding without advancing the Paginator, so the page is not returned by Page,
+	// or counted towards the aggregation of pages. The fetched page is cached, so that the next call to Peek or Next
+	// uses it rather than fetching it again. This is useful for inspecting the next page before deciding whether to keep
+	// it. Note that Peek will fetch a page even if Continue returns false. Like Next, Peek returns the sticky error if
+	// there is one, and an error that occurs whilst fetching the page becomes the sticky error.
+	Peek() (RetT, error)
 	// All returns all the return values for the Binding at once.
 	All() (RetT, error)
+	// WritePages fetches each page and writes it to the given io.Writer as soon as it has been fetched, after encoding
+	// it using the given function, such as one that encodes each item of a page as a line of NDJSON. This avoids holding
+	// the aggregation of all pages in memory. Pages are transformed by the function set by SetPageTransform before they
+	// are encoded, and pages that are empty according to SetEmptyDetector are skipped. The total number of bytes that
+	// were written is returned, even if an error occurs. WritePages is not named WriteTo, as its signature differs from
+	// io.WriterTo.
+	WritePages(w io.Writer, encode func(page RetT) ([]byte, error)) (int64, error)
+	// AllReversed fetches all the pages in the same way as All, but returns the aggregation of all pages in reverse
+	// order. This is useful for APIs that return items from newest to oldest when the items are required from oldest to
+	// newest. This can only be used when RetT is a slice.
+	AllReversed() (RetT, error)
+	// AllWithin fetches pages in the same way as All, but stops once the given time budget has been spent. The elapsed
+	// time includes any time spent waiting for rate limits. The budget is only checked between pages, so a page that is
+	// being fetched when the budget runs out will still be merged. If the budget is exceeded before all pages have been
+	// fetched, the pages that have been fetched so far are returned along with ErrTimeBudgetExceeded. Unlike a
+	// context.Context deadline, this will not cancel any in-flight request.
+	AllWithin(budget time.Duration) (RetT, error)
 	// Pages fetches the given number of pages from the Binding whilst appending each response slice together.
 	Pages(pages int) (RetT, error)
 	// Until keeps fetching pages until there are no more pages, or the given predicate function returns false.
 	Until(predicate func(paginator Paginator[ResT, RetT], pages RetT) bool) (RetT, error)
+	// UntilReason fetches pages in the same way as Until, but also returns the StopReason for why it stopped fetching
+	// pages. This allows the caller to distinguish between the predicate returning false, there being no more pages,
+	// and an error occurring. This is useful for deciding whether to resume pagination later.
+	UntilReason(predicate func(paginator Paginator[ResT, RetT], pages RetT) bool) (RetT, StopReason, error)
+	// UntilOlderThan keeps fetching pages until there are no more pages, or the last item in a page is older than the
+	// given cutoff. The time of each item is found using the given timeOf function. Items that are older than the cutoff
+	// are trimmed from the returned aggregation, so this assumes that items are returned from newest to oldest. This can
+	// only be used when RetT is a slice.
+	UntilOlderThan(cutoff time.Time, timeOf func(item any) time.Time) (RetT, error)
+	// UntilFound keeps fetching pages until there are no more pages, or a page contains an item for which the given
+	// match function returns true. The aggregation of all pages up to and including the page containing the matching
+	// item is returned. This can only be used when RetT is a slice/array, or a Mergeable that implements Itemable.
+	UntilFound(match func(item any) bool) (RetT, error)
+	// Channel fetches pages in a separate goroutine and sends each page to the returned page channel, which has the
+	// given buffer size. Once the buffer is full, the goroutine will block until the consumer reads a page. If an error
+	// occurs, it is sent to the returned error channel and no more pages are fetched. Both channels are closed once
+	// there are no more pages, an error occurs, the returned stop function is called, or the context.Context of the
+	// Paginator is done. The stop function must be called if the consumer stops reading pages before both channels are
+	// closed, otherwise the goroutine will leak. The goroutine owns the Paginator until both channels are closed, so the
+	// Paginator must not be used by the caller until then.
+	Channel(bufferSize int) (pages <-chan RetT, errs <-chan error, stop context.CancelFunc)
+}
+
+// paginatorBinding is the subset of the Binding interface that is required by a Paginator to fetch pages. Both Binding
+// and BindingWrapper implement paginatorBinding, so the same Paginator implementation can be used for typed and
+// un-typed Paginator(s).
+type paginatorBinding[RetT any] interface {
+	Name() string
+	Params() []BindingParam
+	PaginatorParamSet() PaginatorParamConfig
+	ExecuteRaw(ctx context.Context, client Client, args ...any) (response RetT, responseWrapper reflect.Value, err error)
 }
 
+// The un-typed Paginator returned by NewPaginator is a typedPaginator[any, any] that paginates a BindingWrapper, so we
+// check at compile-time that it implements the whole Paginator[any, any] interface, including methods such as Until
+// that take callbacks with Paginator[any, any] arguments.
+var (
+	_ Paginator[any, any]   = (*typedPaginator[any, any])(nil)
+	_ paginatorBinding[any] = BindingWrapper{}
+)
+
 type typedPaginator[ResT any, RetT any] struct {
+	paginatorOptions
+	ctx                    context.Context
 	client                 Client
 	rateLimitedClient      RateLimitedClient
 	usingRateLimitedClient bool
-	binding                Binding[ResT, RetT]
+	binding                paginatorBinding[RetT]
 	params                 []BindingParam
 	paramSet               paginatorParamSet
 	limitArg               *float64
@@ -154,47 +553,232 @@ type typedPaginator[ResT any, RetT any] struct {
 	args                   []any
 	returnType             reflect.Type
 	page                   int
+	pageSize               int
+	total                  int
+	requests               int
+	nextURL                string
+	nextToken              string
+	hasNextToken           bool
+	lastWrapper            reflect.Value
+	peeked                 *fetchedPage[RetT]
+	err                    error
 	currentPage            RetT
+	pageTransform          func(page RetT) (RetT, error)
+	emptyDetector          func(page RetT) bool
+	merger                 func(aggregate any, page any) (any, error)
+	aggregate              any
+	limitExtractor         LimitExtractor
+	requestLog             [][]any
+	// resumed is set when the Paginator was created using NewPaginatorFromState, and is unset once the next page has
+	// been fetched.
+	resumed       bool
+	resumedDone   bool
+	resumedValues map[string]any
+}
+
+// pageLen returns the length of the given page. If the page is a reflect.Slice/reflect.Array, then the length will be
+// found using reflection. Otherwise, if the page implements Lenable then Lenable.Len is used. The second return value
+// indicates whether the length could be found.
+func pageLen(page any) (int, bool) {
+	if lenable, ok := page.(Lenable); ok {
+		return lenable.Len(), true
+	}
+
+	val := reflect.ValueOf(page)
+	switch val.Kind() {
+	case reflect.Slice, reflect.Array:
+		return val.Len(), true
+	default:
+		return 0, false
+	}
+}
+
+// pageItems returns the items within the given page. The page must either be a slice/array, or implement Itemable.
+func pageItems(page any) ([]any, bool) {
+	if itemable, ok := page.(Itemable); ok {
+		return itemable.Items(), true
+	}
+
+	val := reflect.ValueOf(page)
+	switch val.Kind() {
+	case reflect.Slice, reflect.Array:
+		items := make([]any, val.Len())
+		for i := range items {
+			items[i] = val.Index(i).Interface()
+		}
+		return items, true
+	default:
+		return nil, false
+	}
+}
+
+func (p *typedPaginator[ResT, RetT]) ParamSet() string {
+	return strings.Trim(p.paramSet.String(), "{}")
 }
 
+func (p *typedPaginator[ResT, RetT]) RequestLog() [][]any { return p.requestLog }
+
 func (p *typedPaginator[ResT, RetT]) mergeable() bool {
 	return p.returnType.Implements(reflect.TypeOf((*Mergeable)(nil)).Elem())
 }
 
 func (p *typedPaginator[ResT, RetT]) Continue() bool {
+	return p.err == nil && p.hasNextPage()
+}
+
+// hasNextPage returns whether there is another page to fetch, regardless of whether the Paginator has a sticky error.
+// This is used by State, so that a checkpoint taken after a page failed to be fetched is not treated as finished.
+func (p *typedPaginator[ResT, RetT]) hasNextPage() bool {
+	if p.page == 1 {
+		return true
+	}
+
+	// The current page is not available when resumed from a state, so we use whether the state was done
+	if p.resumed {
+		return !p.resumedDone
+	}
+
+	if p.emptyPage() {
+		return false
+	}
+
+	if p.paramSet == linkHeaderParamSet {
+		return p.nextURL != ""
+	}
+
+	if _, ok := p.paramSet.tokenParam(); ok {
+		return p.hasNextToken
+	}
+
+	// If the response reports its own pagination metadata, then we know exactly whether there are more pages
+	if paged, ok := pagedWrapper(p.lastWrapper); ok {
+		return paged.CurrentPage() < paged.TotalPages()
+	}
+
+	// If the current page is nil or invalid (which can happen for untyped Paginators) then its length cannot be found.
+	// As the first page has already been fetched, we will then assume that there are no more pages.
 	hasMore := false
-	if p.returnType.Implements(reflect.TypeOf((*Mergeable)(nil)).Elem()) {
+	if p.mergeable() {
 		if mergeable, ok := any(p.currentPage).(Mergeable); ok {
 			hasMore = mergeable.HasMore()
 		}
-	} else {
-		hasMore = reflect.ValueOf(p.currentPage).Len() > 0
+	} else if length, ok := pageLen(p.currentPage); ok {
+		hasMore = length > 0
 	}
-	return p.page == 1 || hasMore
+
+	// If the last page that was fetched is shorter than the effective page size, then we can assume that it was the
+	// last page, and so we don't need to make another request to find an empty page. This only applies to pagination
+	// using the "page" param, as APIs that paginate using cursors often return variable-length pages (e.g. when the
+	// results are filtered after they are paged).
+	if length, ok := pageLen(p.currentPage); hasMore && ok && p.paramSet == pageParamSet && p.pageSize > 0 && length < p.pageSize {
+		hasMore = false
+	}
+	return hasMore
 }
 
 func (p *typedPaginator[ResT, RetT]) Page() RetT { return p.currentPage }
 
+func (p *typedPaginator[ResT, RetT]) LastWrapper() reflect.Value { return p.lastWrapper }
+
+func (p *typedPaginator[ResT, RetT]) SetLimitExtractor(extractor LimitExtractor) Paginator[ResT, RetT] {
+	p.limitExtractor = extractor
+	p.limitArg = nil
+	return p
+}
+
+func (p *typedPaginator[ResT, RetT]) SetPageTransform(transform func(page RetT) (RetT, error)) Paginator[ResT, RetT] {
+	p.pageTransform = transform
+	return p
+}
+
+func (p *typedPaginator[ResT, RetT]) SetEmptyDetector(detector func(page RetT) bool) Paginator[ResT, RetT] {
+	p.emptyDetector = detector
+	return p
+}
+
+// emptyPage returns whether the current page is empty according to the function set by SetEmptyDetector.
+func (p *typedPaginator[ResT, RetT]) emptyPage() bool {
+	return p.emptyDetector != nil && p.emptyDetector(p.currentPage)
+}
+
+func (p *typedPaginator[ResT, RetT]) SetMerger(merger func(aggregate any, page any) (any, error)) Paginator[ResT, RetT] {
+	p.merger = merger
+	return p
+}
+
+func (p *typedPaginator[ResT, RetT]) Aggregate() any { return p.aggregate }
+
+// result records the given aggregation of pages as the latest aggregate, and returns it as RetT. If the aggregation is
+// not of type RetT, which can happen when a merger has been set using SetMerger, then the zero value of RetT is
+// returned.
+func (p *typedPaginator[ResT, RetT]) result(pages reflect.Value) (ret RetT) {
+	if !pages.IsValid() {
+		p.aggregate = nil
+		return
+	}
+
+	p.aggregate = pages.Interface()
+	ret, _ = p.aggregate.(RetT)
+	return
+}
+
+func (p *typedPaginator[ResT, RetT]) PageSize() int { return p.pageSize }
+
+func (p *typedPaginator[ResT, RetT]) Requests() int { return p.requests }
+
+// noThrottleContextKey is the context.Context key for the flag set by WithoutThrottling.
+type noThrottleContextKey struct{}
+
+// WithoutThrottling returns a copy of the given context.Context that disables rate limit handling for any Paginator
+// that uses it. A Paginator will not sleep until the latest RateLimit of a RateLimitedClient resets, even if it has
+// expired, and will not retry the first page. This is useful for one-off priority requests, such as health checks,
+// that must not wait. Binding.Execute never sleeps for a RateLimit, so the flag only affects the Paginator, although it
+// is also passed to Client.Run where a Client can read it using ThrottlingDisabled.
+//
+// Because the RateLimit is ignored, the API is likely to respond with 429 (Too Many Requests) errors if the RateLimit
+// has expired, and repeatedly exceeding a RateLimit can get the credentials of a Client blocked by some APIs.
+// RateLimit(s) are still parsed and added to the RateLimitedClient, so other executions continue to respect them. The
+// wait time given to the Paginator still applies.
+func WithoutThrottling(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noThrottleContextKey{}, true)
+}
+
+// ThrottlingDisabled returns whether rate limit handling has been disabled for the given context.Context using
+// WithoutThrottling.
+func ThrottlingDisabled(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	disabled, _ := ctx.Value(noThrottleContextKey{}).(bool)
+	return disabled
+}
+
 func paginatorCheckRateLimit(
+	ctx context.Context,
 	client Client,
 	waitTime time.Duration,
 	bindingName string,
 	limitArg **float64,
+	limitExtractor LimitExtractor,
 	page int,
 	currentPage any,
 	params []BindingParam,
 	args []any,
 ) (ignoreFirstRequest bool, ok bool, err error) {
 	var rateLimitedClient RateLimitedClient
-	if rateLimitedClient, ok = client.(RateLimitedClient); ok {
+	if rateLimitedClient, ok = client.(RateLimitedClient); ok && !ThrottlingDisabled(ctx) {
+		loggedArgs := redactArgs(params, args)
+		logPrefix := traceLogPrefix(ctx)
 		rl := rateLimitedClient.LatestRateLimit(bindingName)
 		tries := 3
 		for rl == nil && tries > 0 {
-			rateLimitedClient.Log(fmt.Sprintf(
+			rateLimitedClient.Log(logPrefix + fmt.Sprintf(
 				"Could not get latest rate limit for %q%v on page no. %d. Trying again in %s (%d tries left)...",
-				bindingName, args, page, waitTime.String(), tries,
+				bindingName, loggedArgs, page, waitTime.String(), tries,
 			))
-			time.Sleep(waitTime)
+			if err = sleepCtx(ctx, waitTime); err != nil {
+				return
+			}
 			rl = rateLimitedClient.LatestRateLimit(bindingName)
 			tries--
 		}
@@ -204,69 +788,58 @@ func paginatorCheckRateLimit(
 			switch rl.Type() {
 			case RequestRateLimit:
 				if rl.Remaining() == 0 {
-					rateLimitedClient.Log(fmt.Sprintf(
+					rateLimitedClient.Log(logPrefix + fmt.Sprintf(
 						"Latest request rate limit for %q%v has expired on page no. %d. Sleeping for %s until %s...",
-						bindingName, args, page, sleepTime.String(), rl.Reset(),
+						bindingName, loggedArgs, page, sleepTime.String(), rl.Reset(),
 					))
-					time.Sleep(sleepTime)
+					if err = sleepCtx(ctx, sleepTime); err != nil {
+						return
+					}
 				}
 			case ResourceRateLimit:
+				// The current page is nil/invalid before the first page is fetched, so we treat it as empty
+				currentPageLen, _ := pageLen(currentPage)
 				cont := func() bool {
-					return page == 1 || reflect.ValueOf(currentPage).Len() > 0
+					return page == 1 || currentPageLen > 0
 				}
 
-				if reflect.ValueOf(currentPage).Len() > rl.Remaining() {
-					rateLimitedClient.Log(fmt.Sprintf(
+				if currentPageLen > rl.Remaining() {
+					rateLimitedClient.Log(logPrefix + fmt.Sprintf(
 						"Latest resource rate limit for %q%v has expired on page no. %d. Sleeping for %s until %s...",
-						bindingName, args, page, sleepTime.String(), rl.Reset(),
+						bindingName, loggedArgs, page, sleepTime.String(), rl.Reset(),
 					))
-					time.Sleep(sleepTime)
+					if err = sleepCtx(ctx, sleepTime); err != nil {
+						return
+					}
 				} else if cont() {
-					if limitArg == nil {
-						for i, param := range params {
-							if !limitParamNames.Contains(param.name) {
-								continue
-							}
-
-							var argVal reflect.Value
-							if i < len(args) {
-								argVal = reflect.ValueOf(args[i])
-							} else if !param.required && !param.variadic {
-								argVal = reflect.ValueOf(param.defaultValue)
-							}
-
-							var val float64
-							switch {
-							case argVal.CanInt():
-								val = float64(argVal.Int())
-							case argVal.CanUint():
-								val = float64(argVal.Uint())
-							case argVal.CanFloat():
-								val = argVal.Float()
-							default:
-								continue
-							}
-							**limitArg = val
-							// Break out of the loop if we have found a limit argument
-							break
+					if *limitArg == nil {
+						extractor := limitExtractor
+						if extractor == nil {
+							extractor = defaultLimitExtractor
+						}
+
+						if val, ok := extractor(params, args); ok {
+							*limitArg = &val
 						}
 					}
 
-					if **limitArg > float64(rl.Remaining()) {
-						rateLimitedClient.Log(fmt.Sprintf(
+					if *limitArg != nil && **limitArg > float64(rl.Remaining()) {
+						rateLimitedClient.Log(logPrefix + fmt.Sprintf(
 							"Latest resource rate limit for %q%v has expired on page no. %d. Sleeping for %s until %s...",
-							bindingName, args, page, sleepTime.String(), rl.Reset(),
+							bindingName, loggedArgs, page, sleepTime.String(), rl.Reset(),
 						))
-						time.Sleep(sleepTime)
+						if err = sleepCtx(ctx, sleepTime); err != nil {
+							return
+						}
 					}
 				}
 			}
 		} else if page == 1 {
 			ignoreFirstRequest = true
 		} else if rl == nil {
-			rateLimitedClient.Log(fmt.Sprintf(
+			rateLimitedClient.Log(logPrefix + fmt.Sprintf(
 				"Could not get the latest rate limit for %q%v on page no. %d",
-				bindingName, args, page,
+				bindingName, loggedArgs, page,
 			))
 			err = fmt.Errorf(
 				"could not get the latest RateLimit/RateLimit has expired but we are on page %d, check Client.Run",
@@ -274,7 +847,7 @@ func paginatorCheckRateLimit(
 			)
 			return
 		} else {
-			rateLimitedClient.Log(fmt.Sprintf(
+			rateLimitedClient.Log(logPrefix + fmt.Sprintf(
 				"Latest rate limit for %q is before the current time: %s - %s = %s, so we are going to execute the binding anyway",
 				bindingName, time.Now().UTC().Format("15:04:05"), rl.Reset().Format("15:04:05"), time.Now().UTC().Sub(rl.Reset()),
 			))
@@ -283,13 +856,117 @@ func paginatorCheckRateLimit(
 	return
 }
 
-func (p *typedPaginator[ResT, RetT]) Next() (err error) {
+// nextLink returns the URL to the next page using the LinkParser of the Paginator. If there is no next page then an
+// empty string is returned.
+func (p *typedPaginator[ResT, RetT]) nextLink() (string, error) {
+	headerRecorder, ok := p.client.(HeaderRecorder)
+	if !ok {
+		return "", fmt.Errorf("Client %T does not implement HeaderRecorder", p.client)
+	}
+
+	parser := p.linkParser
+	if parser == nil {
+		parser = ParseLinkHeader
+	}
+
+	header := headerRecorder.LatestHeader(p.binding.Name())
+	if header == nil {
+		return "", nil
+	}
+	next, _ := parser(header)
+	return next, nil
+}
+
+// initialAfter returns the paginator param values for the first page when the InitialAfter or OmitInitialAfter
+// PaginatorOption(s) are used. The second return value is false if neither PaginatorOption was used.
+func (p *typedPaginator[ResT, RetT]) initialAfter() (map[string]any, bool) {
+	switch {
+	case p.initialAfterSet:
+		return map[string]any{"after": p.paginatorOptions.initialAfter}, true
+	case p.omitInitialAfter:
+		for _, param := range p.params {
+			if param.name == "after" {
+				return map[string]any{"after": param.defaultValue}, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// paginatorValues returns the paginator param values for the next page. These are found from the current page, unless
+// the next page is the first page, or the Paginator was resumed from a state and has not yet fetched a page.
+func (p *typedPaginator[ResT, RetT]) paginatorValues() (paginatorValues map[string]any, err error) {
+	if p.resumedValues != nil {
+		return p.resumedValues, nil
+	}
+
+	if _, ok := p.paramSet.tokenParam(); ok {
+		return p.pageTokenValues(), nil
+	}
+
+	// There is no resource to get the paginator param values from on the first page
+	var resource any = p.currentPage
+	if p.page == 1 {
+		resource = nil
+	}
+
+	var ok bool
+	if p.page == 1 && p.paramSet == afterParamSet {
+		paginatorValues, ok = p.initialAfter()
+	}
+
+	if !ok {
+		if paginatorValues, err = p.paramSet.GetPaginatorParamValue(p.params, resource, p.page); err != nil {
+			err = errors.Wrapf(
+				err, "cannot get paginator param values from %T value on page %d",
+				p.currentPage, p.page,
+			)
+		}
+	}
+	return
+}
+
+// pageTokenValues returns the paginator param values for the next page when paginating using tokens that are extracted
+// from each page. The default value of the token param is used for the first page, unless the InitialAfter
+// PaginatorOption was given when paginating using pageInfoParamSet.
+func (p *typedPaginator[ResT, RetT]) pageTokenValues() map[string]any {
+	name, _ := p.paramSet.tokenParam()
+	switch {
+	case p.page > 1:
+		return map[string]any{name: p.nextToken}
+	case p.paramSet == pageInfoParamSet && p.initialAfterSet:
+		return map[string]any{name: p.initialAfter}
+	}
+
+	for _, param := range p.params {
+		if param.name == name {
+			return map[string]any{name: param.defaultValue}
+		}
+	}
+	return map[string]any{name: ""}
+}
+
+// fetchedPage is a page that has been fetched by typedPaginator.fetch, but that has not yet been advanced past.
+type fetchedPage[RetT any] struct {
+	page         RetT
+	lastWrapper  reflect.Value
+	nextURL      string
+	nextToken    string
+	hasNextToken bool
+}
+
+// fetch fetches the next page from the Binding without advancing the Paginator.
+func (p *typedPaginator[ResT, RetT]) fetch() (fetched *fetchedPage[RetT], err error) {
+	// We wait between pages, rather than after each page, so that a page is never fetched and then discarded because
+	// the context.Context was done whilst waiting
+	if p.page > 1 && p.waitTime != 0 {
+		if err = sleepCtx(p.ctx, p.waitTime); err != nil {
+			return nil, errors.Wrapf(err, "context is done whilst waiting to fetch page no. %d", p.page)
+		}
+	}
+
 	var paginatorValues map[string]any
-	if paginatorValues, err = p.paramSet.GetPaginatorParamValue(p.params, p.currentPage, p.page); err != nil {
-		err = errors.Wrapf(
-			err, "cannot get paginator param values from %T value on page %d",
-			p.currentPage, p.page,
-		)
+	if paginatorValues, err = p.paginatorValues(); err != nil {
 		return
 	}
 
@@ -299,71 +976,287 @@ func (p *typedPaginator[ResT, RetT]) Next() (err error) {
 			err, "cannot insert paginator values (%v) into arguments for page %d",
 			paginatorValues, p.page,
 		)
+		return
+	}
+
+	if p.recordRequests {
+		p.requestLog = append(p.requestLog, append([]any(nil), args...))
 	}
 
+	fetched = &fetchedPage[RetT]{}
 	var ignoreFirstRequest bool
 	execute := func() (ret RetT, err error) {
 		if ignoreFirstRequest, p.usingRateLimitedClient, err = paginatorCheckRateLimit(
-			p.client, p.waitTime, p.binding.Name(), &p.limitArg, p.page, p.currentPage, p.params, p.args,
+			p.ctx, p.client, p.waitTime, p.binding.Name(), &p.limitArg, p.limitExtractor, p.page, p.currentPage, p.params, args,
 		); err != nil {
 			return
 		}
-		return p.binding.Execute(p.client, args...)
+		ctx := p.ctx
+		if p.paramSet == linkHeaderParamSet && p.nextURL != "" {
+			ctx = context.WithValue(ctx, requestURLContextKey{}, p.nextURL)
+		}
+
+		// The timeout only applies to the execution of the Binding, and not to the rate limit check above
+		if p.requestTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, p.requestTimeout)
+			defer cancel()
+		}
+		p.requests++
+		ret, fetched.lastWrapper, err = p.binding.ExecuteRaw(ctx, p.client, args...)
+		return
 	}
 
-	if p.currentPage, err = execute(); err != nil {
+	if fetched.page, err = execute(); err != nil {
 		if !ignoreFirstRequest {
-			err = errors.Wrapf(err, "error occurred on page no. %d", p.page)
-			return
+			return nil, errors.Wrapf(err, "error occurred on page no. %d", p.page)
 		}
 
-		if p.currentPage, err = execute(); err != nil {
-			err = errors.Wrapf(
+		if fetched.page, err = execute(); err != nil {
+			return nil, errors.Wrapf(
 				err, "error occurred on page no. %d, after ignoring the first request due to no rate limit",
 				p.page,
 			)
+		}
+	}
+
+	if p.paramSet == linkHeaderParamSet {
+		if fetched.nextURL, err = p.nextLink(); err != nil {
+			return nil, errors.Wrapf(err, "cannot find the next link after page no. %d", p.page)
+		}
+	}
+
+	if _, ok := p.paramSet.tokenParam(); ok {
+		fetched.nextToken, fetched.hasNextToken = p.pageTokenExtractor(fetched.page)
+	}
+	return
+}
+
+func (p *typedPaginator[ResT, RetT]) Peek() (page RetT, err error) {
+	if p.err != nil {
+		return page, p.err
+	}
+
+	if p.peeked == nil {
+		if p.peeked, err = p.fetch(); err != nil {
+			p.err = err
+			return
+		}
+	}
+	return p.peeked.page, nil
+}
+
+func (p *typedPaginator[ResT, RetT]) Err() error { return p.err }
+
+func (p *typedPaginator[ResT, RetT]) ClearErr() { p.err = nil }
+
+func (p *typedPaginator[ResT, RetT]) Next() (err error) {
+	if p.err != nil {
+		return p.err
+	}
+
+	// A page that has been fetched by Peek is used instead of fetching the page again
+	fetched := p.peeked
+	if fetched == nil {
+		if fetched, err = p.fetch(); err != nil {
+			p.err = err
 			return
 		}
 	}
+	p.peeked = nil
+
+	p.currentPage, p.lastWrapper = fetched.page, fetched.lastWrapper
+	p.resumed, p.resumedValues = false, nil
+	if p.paramSet == linkHeaderParamSet {
+		p.nextURL = fetched.nextURL
+	}
+
+	if _, ok := p.paramSet.tokenParam(); ok {
+		p.nextToken, p.hasNextToken = fetched.nextToken, fetched.hasNextToken
+	}
+
+	// The length of the first page is taken as the effective page size
+	length, _ := pageLen(p.currentPage)
+	if p.page == 1 {
+		p.pageSize = length
+	}
+
+	p.total += length
+	if p.onPage != nil {
+		p.onPage(p.page, length, p.total)
+	}
 
 	p.page++
-	if p.waitTime != 0 {
-		time.Sleep(p.waitTime)
+	return
+}
+
+// sleepCtx sleeps for the given duration, or until the given context.Context is done, in which case the error of the
+// context.Context is returned.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// transformedPage returns the current page after it has been transformed by the function set by SetPageTransform.
+func (p *typedPaginator[ResT, RetT]) transformedPage() (page RetT, err error) {
+	page = p.Page()
+	if p.pageTransform != nil {
+		if page, err = p.pageTransform(page); err != nil {
+			err = errors.Wrapf(err, "could not transform page no. %d", p.page-1)
+		}
 	}
 	return
 }
 
 func (p *typedPaginator[ResT, RetT]) merge(pages reflect.Value) (reflect.Value, error) {
+	if p.emptyPage() {
+		return pages, nil
+	}
+
+	page, err := p.transformedPage()
+	if err != nil {
+		return pages, err
+	}
+	return p.mergePage(pages, page)
+}
+
+// mergePage merges the given page, which has already been transformed, into the given aggregation of pages.
+func (p *typedPaginator[ResT, RetT]) mergePage(pages reflect.Value, page RetT) (_ reflect.Value, err error) {
+	if p.merger != nil {
+		// The aggregate starts off as the zero value of RetT, which is passed to the merger as nil
+		var aggregate any
+		if pages.IsValid() && (pages.Type() != p.returnType || !pages.IsZero()) {
+			aggregate = pages.Interface()
+		}
+
+		if aggregate, err = p.merger(aggregate, page); err != nil {
+			return pages, errors.Wrapf(err, "could not merge page no. %d", p.page-1)
+		}
+		return reflect.ValueOf(aggregate), nil
+	}
+
 	mergeable := p.mergeable()
 	if mergeable {
-		if p.page == 2 {
-			pages = reflect.ValueOf(p.currentPage)
+		// If we have just fetched the first page, or the aggregation is empty (such as when the Paginator was resumed
+		// from a state), then we will set pages to be the value of the current page
+		if p.page == 2 || pages.IsZero() {
+			pages = reflect.ValueOf(page)
 		} else {
-			if err := pages.Interface().(Mergeable).Merge(p.Page()); err != nil {
+			if err = pages.Interface().(Mergeable).Merge(page); err != nil {
 				return pages, err
 			}
 		}
 	} else {
-		pages = reflect.AppendSlice(pages, reflect.ValueOf(p.Page()))
+		pages = appendPage(pages, reflect.ValueOf(page))
 	}
 	return pages, nil
 }
 
-func (p *typedPaginator[ResT, RetT]) All() (RetT, error) {
-	pages := reflect.New(p.returnType).Elem()
+// appendPage appends the given page onto the given aggregation of pages. When the aggregation does not have enough
+// capacity for the page, its capacity is doubled, rather than relying on the growth of reflect.AppendSlice which slows
+// to 1.25x for large slices. This reduces the number of reallocations and copies when aggregating many pages.
+func appendPage(pages reflect.Value, page reflect.Value) reflect.Value {
+	if needed := pages.Len() + page.Len(); needed > pages.Cap() {
+		grown := reflect.MakeSlice(pages.Type(), pages.Len(), needed*2)
+		reflect.Copy(grown, pages)
+		pages = grown
+	}
+	return reflect.AppendSlice(pages, page)
+}
+
+func (p *typedPaginator[ResT, RetT]) WritePages(w io.Writer, encode func(page RetT) ([]byte, error)) (written int64, err error) {
 	for p.Continue() {
-		var err error
-		// Fetch the next page...
 		if err = p.Next(); err != nil {
-			return pages.Interface().(RetT), err
+			return
+		}
+
+		if p.emptyPage() {
+			continue
+		}
+
+		var page RetT
+		if page, err = p.transformedPage(); err != nil {
+			return
+		}
+
+		var data []byte
+		if data, err = encode(page); err != nil {
+			return written, errors.Wrapf(err, "could not encode page no. %d", p.page-1)
+		}
+
+		n, writeErr := w.Write(data)
+		if written += int64(n); writeErr != nil {
+			return written, errors.Wrapf(writeErr, "could not write page no. %d", p.page-1)
+		}
+	}
+	return
+}
+
+func (p *typedPaginator[ResT, RetT]) All() (RetT, error) {
+	pages := reflect.New(p.returnType).Elem()
+	for p.Continue() {
+		var err error
+		// Fetch the next page...
+		if err = p.Next(); err != nil {
+			return p.result(pages), err
+		}
+
+		// ...merge the current page into the aggregation of all pages
+		if pages, err = p.merge(pages); err != nil {
+			return p.result(pages), err
+		}
+	}
+	return p.result(pages), nil
+}
+
+func (p *typedPaginator[ResT, RetT]) AllWithin(budget time.Duration) (RetT, error) {
+	start := time.Now()
+	pages := reflect.New(p.returnType).Elem()
+	for p.Continue() {
+		if time.Since(start) >= budget {
+			return p.result(pages), ErrTimeBudgetExceeded
+		}
+
+		var err error
+		// Fetch the next page...
+		if err = p.Next(); err != nil {
+			return p.result(pages), err
 		}
 
 		// ...merge the current page into the aggregation of all pages
 		if pages, err = p.merge(pages); err != nil {
-			return pages.Interface().(RetT), err
+			return p.result(pages), err
 		}
 	}
-	return pages.Interface().(RetT), nil
+	return p.result(pages), nil
+}
+
+func (p *typedPaginator[ResT, RetT]) AllReversed() (RetT, error) {
+	if p.returnType.Kind() != reflect.Slice {
+		return reflect.New(p.returnType).Elem().Interface().(RetT), fmt.Errorf(
+			"cannot reverse pages as return type %v is not a slice",
+			p.returnType,
+		)
+	}
+
+	pages, err := p.All()
+	if err != nil {
+		return pages, err
+	}
+
+	// Reverse the aggregation of all pages in place
+	pagesValue := reflect.ValueOf(pages)
+	swap := reflect.Swapper(pages)
+	for i, j := 0, pagesValue.Len()-1; i < j; i, j = i+1, j-1 {
+		swap(i, j)
+	}
+	return pages, nil
 }
 
 func (p *typedPaginator[ResT, RetT]) Pages(pageNo int) (RetT, error) {
@@ -372,82 +1265,192 @@ func (p *typedPaginator[ResT, RetT]) Pages(pageNo int) (RetT, error) {
 		var err error
 		// Fetch the next page...
 		if err = p.Next(); err != nil {
-			return pages.Interface().(RetT), err
+			return p.result(pages), err
 		}
 
 		// ...merge the current page into the aggregation of all pages
 		if pages, err = p.merge(pages); err != nil {
-			return pages.Interface().(RetT), err
+			return p.result(pages), err
 		}
 	}
-	return pages.Interface().(RetT), nil
+	return p.result(pages), nil
 }
 
 func (p *typedPaginator[ResT, RetT]) Until(predicate func(paginator Paginator[ResT, RetT], pages RetT) bool) (RetT, error) {
+	pages, _, err := p.UntilReason(predicate)
+	return pages, err
+}
+
+func (p *typedPaginator[ResT, RetT]) UntilReason(predicate func(paginator Paginator[ResT, RetT], pages RetT) bool) (RetT, StopReason, error) {
 	pages := reflect.New(p.returnType).Elem()
-	for p.Continue() && predicate(p, pages.Interface().(RetT)) {
+	for p.Continue() {
+		if !predicate(p, p.result(pages)) {
+			return p.result(pages), StopPredicate, nil
+		}
+
 		var err error
 		// Fetch the next page...
 		if err = p.Next(); err != nil {
-			return pages.Interface().(RetT), err
+			return p.result(pages), StopError, err
 		}
 
 		// ...merge the current page into the aggregation of all pages
 		if pages, err = p.merge(pages); err != nil {
-			return pages.Interface().(RetT), err
+			return p.result(pages), StopError, err
 		}
 	}
-	return pages.Interface().(RetT), nil
+	return p.result(pages), StopExhausted, nil
 }
 
-// NewTypedPaginator creates a new type aware Paginator using the given Client, wait time.Duration, and arguments for
-// the given Binding. The given Binding's Binding.Paginated method must return true, and the return type (RetT) of the
-// Binding must be a slice-type, otherwise an appropriate error will be returned.
-//
-// The Paginator requires one of the following sets of BindingParam(s) taken by the given Binding:
-//  1. ("page",): a singular page argument where each time Paginator.Next is called the page will be incremented
-//  2. ("after",): a singular after argument where each time Paginator.Next is called the Afterable.After method will be
-//     called on the returned response and the returned value will be set as the "after" parameter for the next
-//     Binding.Execute. This requires the RetT to implement the Afterable interface.
-//
-// The sets of BindingParam(s) shown above are given in priority order. This means that a Binding that defines multiple
-// BindingParam(s) that exist within these sets, only the first complete set will be taken.
-//
-// The args given to NewTypedPaginator should not include the set of BindingParam(s) (listed above), that are going to
-// be used to paginate the binding.
-//
-// If the given Client also implements RateLimitedClient then the given waitTime argument will be ignored in favour of
-// waiting (or not) until the RateLimit for the given Binding resets. If the RateLimit that is returned by
-// RateLimitedClient.LatestRateLimit is of type ResourceRateLimit, and the Paginator is on the first page. The following
-// parameter arguments will be checked for a limit/count value to see whether there is enough RateLimit.Remaining (in
-// priority order):
-//  1. "limit"
-//  2. "count"
-func NewTypedPaginator[ResT any, RetT any](client Client, waitTime time.Duration, binding Binding[ResT, RetT], args ...any) (paginator Paginator[ResT, RetT], err error) {
-	if !binding.Paginated() {
-		err = fmt.Errorf("cannot create typed Paginator as Binding is not pagenatable")
-		return
+func (p *typedPaginator[ResT, RetT]) UntilOlderThan(cutoff time.Time, timeOf func(item any) time.Time) (RetT, error) {
+	pages := reflect.New(p.returnType).Elem()
+	if p.returnType.Kind() != reflect.Slice {
+		return p.result(pages), fmt.Errorf(
+			"cannot fetch pages until cutoff as return type %v is not a slice",
+			p.returnType,
+		)
 	}
 
+	for p.Continue() {
+		// Fetch the next page...
+		if err := p.Next(); err != nil {
+			return p.result(pages), err
+		}
+
+		// ...append each item in the current page that is not older than the cutoff...
+		transformed, err := p.transformedPage()
+		if err != nil {
+			return p.result(pages), err
+		}
+		page := reflect.ValueOf(transformed)
+		for i := 0; i < page.Len(); i++ {
+			if item := page.Index(i); !timeOf(item.Interface()).Before(cutoff) {
+				pages = reflect.Append(pages, item)
+			}
+		}
+
+		// ...and stop if the last item in the current page is older than the cutoff
+		if page.Len() > 0 && timeOf(page.Index(page.Len()-1).Interface()).Before(cutoff) {
+			break
+		}
+	}
+	return p.result(pages), nil
+}
+
+func (p *typedPaginator[ResT, RetT]) UntilFound(match func(item any) bool) (RetT, error) {
+	pages := reflect.New(p.returnType).Elem()
+	switch {
+	case p.returnType.Kind() == reflect.Slice, p.returnType.Kind() == reflect.Array:
+	case p.mergeable() && p.returnType.Implements(reflect.TypeOf((*Itemable)(nil)).Elem()):
+	default:
+		return p.result(pages), fmt.Errorf(
+			"cannot search pages for an item as return type %v is not a slice/array or an Itemable Mergeable",
+			p.returnType,
+		)
+	}
+
+	for p.Continue() {
+		// Fetch the next page...
+		if err := p.Next(); err != nil {
+			return p.result(pages), err
+		}
+
+		// ...check whether the current page contains a matching item...
+		page, err := p.transformedPage()
+		if err != nil {
+			return p.result(pages), err
+		}
+
+		found := false
+		items, _ := pageItems(page)
+		for _, item := range items {
+			if found = match(item); found {
+				break
+			}
+		}
+
+		// ...merge the current page into the aggregation of all pages, and stop if it contained a matching item
+		if pages, err = p.mergePage(pages, page); err != nil || found {
+			return p.result(pages), err
+		}
+	}
+	return p.result(pages), nil
+}
+
+func (p *typedPaginator[ResT, RetT]) Channel(bufferSize int) (<-chan RetT, <-chan error, context.CancelFunc) {
+	pages := make(chan RetT, bufferSize)
+	errs := make(chan error, 1)
+
+	// The goroutine fetches pages using a context.Context derived from the Paginator's so that it can be stopped using
+	// the returned stop function. The Paginator's original context.Context is restored before the channels are closed.
+	parent := p.ctx
+	ctx, cancel := context.WithCancel(parent)
+	p.ctx = ctx
+	go func() {
+		defer close(pages)
+		defer close(errs)
+		defer cancel()
+		defer func() { p.ctx = parent }()
+		for p.Continue() {
+			if err := p.Next(); err != nil {
+				errs <- err
+				return
+			}
+
+			select {
+			case pages <- p.Page():
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+	}()
+	return pages, errs, cancel
+}
+
+// newPaginator constructs the typedPaginator that is used by both NewTypedPaginator and NewPaginator. The given
+// returnType must be the return type of the given paginatorBinding, and the given bindingType is used purely within
+// error messages.
+func newPaginator[ResT any, RetT any](ctx context.Context, client Client, waitTime time.Duration, binding paginatorBinding[RetT], bindingType string, returnType reflect.Type, args ...any) (paginator *typedPaginator[ResT, RetT], err error) {
 	p := &typedPaginator[ResT, RetT]{
+		ctx:      ctx,
 		client:   client,
 		binding:  binding,
-		params:   binding.Params(),
 		waitTime: waitTime,
-		args:     args,
 		page:     1,
 	}
+	p.args, p.paginatorOptions = splitPaginatorOptions(args)
+	if p.noThrottle {
+		p.ctx = WithoutThrottling(p.ctx)
+	}
+	paramConfig := binding.PaginatorParamSet()
+	if p.params, err = paramConfig.rename(binding.Params()); err != nil {
+		err = errors.Wrapf(err, "cannot create Paginator for %s", bindingType)
+		return
+	}
+	if len(p.limitParamNames) > 0 {
+		p.limitExtractor = limitParamExtractor(mapset.NewSet(p.limitParamNames...))
+	}
 
 	p.rateLimitedClient, p.usingRateLimitedClient = client.(RateLimitedClient)
-	if p.paramSet = checkPaginatorParams(p.params); p.paramSet == unknownParamSet {
+	if p.paramSet = p.paginatorOptions.paramSet; p.paramSet != unknownParamSet {
+		if _, ok := client.(HeaderRecorder); !ok && p.paramSet == linkHeaderParamSet {
+			err = fmt.Errorf("cannot create Paginator that uses Link headers as Client %T is not a HeaderRecorder", client)
+			return
+		}
+
+		if name, ok := p.paramSet.tokenParam(); ok && checkPaginatorParams(p.params, p.paramSet) != p.paramSet {
+			err = fmt.Errorf("cannot create Paginator that uses extracted tokens as %s has no %q param", bindingType, name)
+			return
+		}
+	} else if p.paramSet = checkPaginatorParams(p.params, paramConfig.sets()...); p.paramSet == unknownParamSet {
 		err = fmt.Errorf(
-			"cannot create typed Paginator as we couldn't find any paginateable params, need one of the following sets of params %v",
+			"cannot create Paginator as we couldn't find any paginateable params, need one of the following sets of params %v",
 			unknownParamSet.Sets(),
 		)
 		return
 	}
 
-	returnType := reflect.ValueOf(new(RetT)).Elem().Type()
 	if returnType.Implements(reflect.TypeOf((*Mergeable)(nil)).Elem()) {
 		p.returnType = returnType
 	} else {
@@ -456,8 +1459,8 @@ func NewTypedPaginator[ResT any, RetT any](client Client, waitTime time.Duration
 			p.returnType = returnType
 		default:
 			err = fmt.Errorf(
-				"cannot create typed Paginator for Binding[%v, %v] that has a non-slice/array return type",
-				reflect.ValueOf(new(ResT)).Elem().Type(), returnType,
+				"cannot create Paginator for %s that has a non-slice/array return type",
+				bindingType,
 			)
 			return
 		}
@@ -466,212 +1469,192 @@ func NewTypedPaginator[ResT any, RetT any](client Client, waitTime time.Duration
 	return
 }
 
-// MustTypePaginate calls NewTypedPaginator with the given arguments and panics if an error occurs.
-func MustTypePaginate[ResT any, RetT any](client Client, waitTime time.Duration, binding Binding[ResT, RetT], args ...any) (paginator Paginator[ResT, RetT]) {
-	var err error
-	if paginator, err = NewTypedPaginator(client, waitTime, binding, args...); err != nil {
-		panic(err)
-	}
-	return
-}
-
-type paginator struct {
-	client                 Client
-	rateLimitedClient      RateLimitedClient
-	usingRateLimitedClient bool
-	binding                *BindingWrapper
-	params                 []BindingParam
-	paramSet               paginatorParamSet
-	limitArg               *float64
-	waitTime               time.Duration
-	args                   []any
-	returnType             reflect.Type
-	page                   int
-	currentPage            any
-}
-
-func (p *paginator) mergeable() bool {
-	return p.returnType.Implements(reflect.TypeOf((*Mergeable)(nil)).Elem())
-}
-
-func (p *paginator) Continue() bool {
-	hasMore := false
-	if p.returnType.Implements(reflect.TypeOf((*Mergeable)(nil)).Elem()) {
-		if mergeable, ok := p.currentPage.(Mergeable); ok {
-			hasMore = mergeable.HasMore()
-		}
-	} else {
-		hasMore = reflect.ValueOf(p.currentPage).Len() > 0
-	}
-	return p.page == 1 || hasMore
+// NewTypedPaginator calls NewTypedPaginatorCtx with context.Background.
+func NewTypedPaginator[ResT any, RetT any](client Client, waitTime time.Duration, binding Binding[ResT, RetT], args ...any) (paginator Paginator[ResT, RetT], err error) {
+	return NewTypedPaginatorCtx(context.Background(), client, waitTime, binding, args...)
 }
 
-func (p *paginator) Page() any { return p.currentPage }
-
-func (p *paginator) Next() (err error) {
-	var paginatorValues map[string]any
-	if paginatorValues, err = p.paramSet.GetPaginatorParamValue(p.params, p.currentPage, p.page); err != nil {
-		err = errors.Wrapf(
-			err, "cannot get paginator param values from %T value on page %d",
-			p.currentPage, p.page,
-		)
+// NewTypedPaginatorCtx creates a new type aware Paginator using the given Client, wait time.Duration, and arguments for
+// the given Binding. The given Binding's Binding.Paginated method must return true, and the return type (RetT) of the
+// Binding must be a slice-type, otherwise an appropriate error will be returned.
+//
+// The Paginator requires one of the following sets of BindingParam(s) taken by the given Binding:
+//  1. ("page",): a singular page argument where each time Paginator.Next is called the page will be incremented
+//  2. ("after",): a singular after argument where each time Paginator.Next is called the Afterable.After method will be
+//     called on the returned response and the returned value will be set as the "after" parameter for the next
+//     Binding.Execute. This requires the RetT to implement the Afterable interface.
+//
+// The sets of BindingParam(s) shown above are given in priority order. This means that a Binding that defines multiple
+// BindingParam(s) that exist within these sets, only the first complete set will be taken. If the pagination params of
+// the Binding have different names, then they can be mapped explicitly using Binding.SetPaginatorParamSet, which also
+// overrides this detection.
+//
+// The args given to NewTypedPaginator should not include the set of BindingParam(s) (listed above), that are going to
+// be used to paginate the binding. PaginatorOption(s) can also be given within the args to configure the Paginator.
+// These will be removed from the args that are passed to the Binding.
+//
+// The length of the first page fetched by the Paginator is taken as the effective page size (see Paginator.PageSize).
+// When paginating using the "page" param, if a subsequent page is shorter than this page size, then it is assumed to be
+// the last page, and Paginator.Continue will return false without requesting an empty page. Paginator(s) that use any
+// other set of params carry on until the end of pages is signalled in another way, as cursor-based APIs often return
+// pages of varying length.
+//
+// The Paginator waits for the given waitTime between each page. If the context.Context is done whilst waiting, or
+// whilst fetching a page, then the error of the context.Context is returned along with the aggregation of the pages
+// that were fetched before it was done, by All, Pages, Until, UntilReason, and UntilFound.
+//
+// If the given Client also implements RateLimitedClient then the given waitTime argument will be ignored in favour of
+// waiting (or not) until the RateLimit for the given Binding resets. If the RateLimit that is returned by
+// RateLimitedClient.LatestRateLimit is of type ResourceRateLimit, and the Paginator is on the first page. The following
+// parameter arguments will be checked for a limit/count value to see whether there is enough RateLimit.Remaining (in
+// priority order):
+//  1. "limit"
+//  2. "count"
+//
+// Each page is fetched by calling Binding.ExecuteCtx with the given context.Context.
+func NewTypedPaginatorCtx[ResT any, RetT any](ctx context.Context, client Client, waitTime time.Duration, binding Binding[ResT, RetT], args ...any) (paginator Paginator[ResT, RetT], err error) {
+	if !binding.Paginated() {
+		err = fmt.Errorf("cannot create typed Paginator as Binding is not pagenatable")
 		return
 	}
 
-	var args []any
-	if args, err = p.paramSet.InsertPaginatorParamValues(p.params, p.args, paginatorValues); err != nil {
-		err = errors.Wrapf(
-			err, "cannot insert paginator values (%v) into arguments for page %d",
-			paginatorValues, p.page,
-		)
+	var p *typedPaginator[ResT, RetT]
+	if p, err = newPaginator[ResT, RetT](
+		ctx, client, waitTime, binding,
+		fmt.Sprintf("Binding[%v, %v]", reflect.ValueOf(new(ResT)).Elem().Type(), reflect.ValueOf(new(RetT)).Elem().Type()),
+		reflect.ValueOf(new(RetT)).Elem().Type(), args...,
+	); err != nil {
+		err = errors.Wrap(err, "cannot create typed Paginator")
+		return
 	}
-	//fmt.Println("paginatorValues", paginatorValues, len(paginatorValues))
-	//fmt.Println("args", args, len(args))
+	paginator = p
+	return
+}
 
-	var ignoreFirstRequest bool
-	execute := func() (err error) {
-		if ignoreFirstRequest, p.usingRateLimitedClient, err = paginatorCheckRateLimit(
-			p.client, p.waitTime, p.binding.Name(), &p.limitArg, p.page, p.currentPage, p.params, p.args,
-		); err != nil {
-			return
-		}
+// paginatorState is the JSON-serialisable state of a Paginator that is returned by Paginator.State.
+type paginatorState struct {
+	ParamSet  string          `json:"paramSet"`
+	Page      int             `json:"page"`
+	PageSize  int             `json:"pageSize"`
+	Total     int             `json:"total"`
+	WaitTime  time.Duration   `json:"waitTime"`
+	After     json.RawMessage `json:"after,omitempty"`
+	NextURL   string          `json:"nextURL,omitempty"`
+	NextToken string          `json:"nextToken,omitempty"`
+	Done      bool            `json:"done"`
+}
 
-		if p.currentPage, err = p.binding.Execute(p.client, args...); err != nil {
-			err = errors.Wrapf(err, "error occurred on page no. %d", p.page)
-		}
-		return
+func (p *typedPaginator[ResT, RetT]) State() (data []byte, err error) {
+	state := paginatorState{
+		ParamSet:  p.ParamSet(),
+		Page:      p.page,
+		PageSize:  p.pageSize,
+		Total:     p.total,
+		WaitTime:  p.waitTime,
+		NextURL:   p.nextURL,
+		NextToken: p.nextToken,
+		Done:      !p.hasNextPage(),
 	}
 
-	if err = execute(); err != nil {
-		if !ignoreFirstRequest {
+	if p.paramSet == afterParamSet && p.page > 1 && !state.Done {
+		var values map[string]any
+		if values, err = p.paginatorValues(); err != nil {
+			err = errors.Wrap(err, "cannot find \"after\" cursor for the next page")
 			return
 		}
 
-		if err = execute(); err != nil {
-			err = errors.Wrapf(
-				err, "error occurred on page no. %d, after ignoring the first request due to no rate limit",
-				p.page,
-			)
+		if state.After, err = json.Marshal(values["after"]); err != nil {
+			err = errors.Wrapf(err, "cannot serialise \"after\" cursor %v", values["after"])
 			return
 		}
 	}
+	return json.Marshal(state)
+}
 
-	p.page++
-	if p.waitTime != 0 {
-		time.Sleep(p.waitTime)
-	}
-	return
+// NewPaginatorFromState calls NewPaginatorFromStateCtx with context.Background.
+func NewPaginatorFromState[ResT any, RetT any](client Client, binding Binding[ResT, RetT], state []byte, args ...any) (paginator Paginator[ResT, RetT], err error) {
+	return NewPaginatorFromStateCtx(context.Background(), client, binding, state, args...)
 }
 
-func (p *paginator) merge(pages reflect.Value) (reflect.Value, error) {
-	mergeable := p.mergeable()
-	if mergeable {
-		// If we have just fetched the first page then we will set pages to be the value of the first page
-		if p.page == 2 {
-			pages = reflect.ValueOf(p.currentPage)
-		} else {
-			if err := pages.Interface().(Mergeable).Merge(p.Page()); err != nil {
-				return reflect.ValueOf(nil), err
-			}
-		}
-	} else {
-		pages = reflect.AppendSlice(pages, reflect.ValueOf(p.Page()))
+// NewPaginatorFromStateCtx creates a new type aware Paginator in the same way as NewTypedPaginatorCtx, but resumes from
+// the given state that was returned by Paginator.State. The wait time of the Paginator is also restored from the state.
+// The given args (and PaginatorOption(s)) should be the same as those given to the Paginator that the state was taken
+// from, and the Binding should paginate using the same set of params. Otherwise, an error will be returned.
+func NewPaginatorFromStateCtx[ResT any, RetT any](ctx context.Context, client Client, binding Binding[ResT, RetT], state []byte, args ...any) (paginator Paginator[ResT, RetT], err error) {
+	var s paginatorState
+	if err = json.Unmarshal(state, &s); err != nil {
+		err = errors.Wrap(err, "cannot unmarshal Paginator state")
+		return
 	}
-	return pages, nil
-}
 
-func (p *paginator) All() (any, error) {
-	pages := reflect.New(p.returnType).Elem()
-	for p.Continue() {
-		var err error
-		// Fetch the next page...
-		if err = p.Next(); err != nil {
-			return pages.Interface(), err
-		}
+	var p Paginator[ResT, RetT]
+	if p, err = NewTypedPaginatorCtx(ctx, client, s.WaitTime, binding, args...); err != nil {
+		return
+	}
 
-		// ...merge the current page into the aggregation of all pages
-		if pages, err = p.merge(pages); err != nil {
-			return pages.Interface(), err
-		}
+	tp := p.(*typedPaginator[ResT, RetT])
+	if tp.ParamSet() != s.ParamSet {
+		err = fmt.Errorf("cannot resume Paginator that paginates using %q from a state that paginates using %q", tp.ParamSet(), s.ParamSet)
+		return
 	}
-	return pages.Interface(), nil
-}
 
-func (p *paginator) Pages(pageNo int) (any, error) {
-	pages := reflect.New(p.returnType).Elem()
-	for p.Continue() && p.page <= pageNo {
-		var err error
-		// Fetch the next page...
-		if err = p.Next(); err != nil {
-			return pages.Interface(), err
-		}
+	tp.page, tp.pageSize, tp.total, tp.nextURL = s.Page, s.PageSize, s.Total, s.NextURL
+	tp.resumed, tp.resumedDone = s.Page > 1, s.Done
+	if tp.paramSet == afterParamSet && len(s.After) > 0 {
+		for _, param := range tp.params {
+			if param.name != "after" {
+				continue
+			}
 
-		// ...merge the current page into the aggregation of all pages
-		if pages, err = p.merge(pages); err != nil {
-			return pages.Interface(), err
+			after := reflect.New(param.Type())
+			if err = json.Unmarshal(s.After, after.Interface()); err != nil {
+				err = errors.Wrapf(err, "cannot unmarshal \"after\" cursor %s into %v", s.After, param.Type())
+				return
+			}
+			tp.resumedValues = map[string]any{"after": after.Elem().Interface()}
 		}
 	}
-	return pages.Interface(), nil
-}
 
-func (p *paginator) Until(predicate func(paginator Paginator[any, any], pages any) bool) (any, error) {
-	pages := reflect.New(p.returnType).Elem()
-	for p.Continue() && predicate(p, pages.Interface()) {
-		var err error
-		// Fetch the next page...
-		if err = p.Next(); err != nil {
-			return pages.Interface(), err
-		}
+	if name, ok := tp.paramSet.tokenParam(); ok && tp.resumed {
+		tp.nextToken, tp.hasNextToken = s.NextToken, !s.Done
+		tp.resumedValues = map[string]any{name: s.NextToken}
+	}
+	paginator = tp
+	return
+}
 
-		// ...merge the current page into the aggregation of all pages
-		if pages, err = p.merge(pages); err != nil {
-			return pages.Interface(), err
-		}
+// MustTypePaginate calls NewTypedPaginator with the given arguments and panics if an error occurs.
+func MustTypePaginate[ResT any, RetT any](client Client, waitTime time.Duration, binding Binding[ResT, RetT], args ...any) (paginator Paginator[ResT, RetT]) {
+	var err error
+	if paginator, err = NewTypedPaginator(client, waitTime, binding, args...); err != nil {
+		panic(err)
 	}
-	return pages.Interface(), nil
+	return
 }
 
-// NewPaginator creates an un-typed Paginator for the given BindingWrapper. It creates a Paginator in a similar way as
-// NewTypedPaginator, except the return type of the Paginator is []any. See NewTypedPaginator for more information on
-// Paginator construction.
+// NewPaginator calls NewPaginatorCtx with context.Background.
 func NewPaginator(client Client, waitTime time.Duration, binding BindingWrapper, args ...any) (pag Paginator[any, any], err error) {
+	return NewPaginatorCtx(context.Background(), client, waitTime, binding, args...)
+}
+
+// NewPaginatorCtx creates an un-typed Paginator for the given BindingWrapper. It creates a Paginator in a similar way
+// as NewTypedPaginatorCtx, except the return type of the Paginator is []any. See NewTypedPaginatorCtx for more
+// information on Paginator construction.
+func NewPaginatorCtx(ctx context.Context, client Client, waitTime time.Duration, binding BindingWrapper, args ...any) (pag Paginator[any, any], err error) {
 	if !binding.Paginated() {
 		err = fmt.Errorf("cannot create a Paginator as Binding is not pagenatable")
 		return
 	}
 
-	p := &paginator{
-		client:   client,
-		binding:  &binding,
-		params:   binding.Params(),
-		waitTime: waitTime,
-		args:     args,
-		page:     1,
-	}
-
-	p.rateLimitedClient, p.usingRateLimitedClient = client.(RateLimitedClient)
-	if p.paramSet = checkPaginatorParams(p.params); p.paramSet == unknownParamSet {
-		err = fmt.Errorf(
-			"cannot create a Paginator as we couldn't find any paginateable params, need one of the following sets of params %v",
-			unknownParamSet.Sets(),
-		)
+	var p *typedPaginator[any, any]
+	if p, err = newPaginator[any, any](
+		ctx, client, waitTime, binding,
+		fmt.Sprintf("Binding[%v, %v]", binding.responseType, binding.returnType),
+		binding.returnType, args...,
+	); err != nil {
+		err = errors.Wrap(err, "cannot create a Paginator")
 		return
 	}
-
-	if binding.returnType.Implements(reflect.TypeOf((*Mergeable)(nil)).Elem()) {
-		p.returnType = binding.returnType
-	} else {
-		switch binding.returnType.Kind() {
-		case reflect.Slice, reflect.Array:
-			p.returnType = binding.returnType
-		default:
-			err = fmt.Errorf(
-				"cannot create a Paginator for Binding[%v, %v] that has a non-slice/array return type",
-				binding.responseType, binding.returnType,
-			)
-			return
-		}
-	}
 	pag = p
 	return
 }
@@ -684,3 +1667,51 @@ func MustPaginate(client Client, waitTime time.Duration, binding BindingWrapper,
 	}
 	return
 }
+
+// Seq2 is an iterator over sequences of pairs of values. It has the same definition as iter.Seq2 in newer versions of
+// Go, so it can be ranged over directly when using Go 1.23 or later. Otherwise, it can be called with a yield function
+// that returns false to stop the iteration.
+type Seq2[K any, V any] func(yield func(K, V) bool)
+
+// Items returns an iterator over each individual item within the pages fetched by the given Paginator, rather than
+// over each page. Each page must be a slice/array, or a Mergeable that implements Itemable, and each item must be of
+// type Item. Pages are fetched lazily as the iterator is advanced, and are not transformed by the function set using
+// Paginator.SetPageTransform. If an error occurs, it is yielded along with the zero value of Item and the iteration
+// stops. For example, with Go 1.23 or later:
+//
+//	for item, err := range Items[Product](paginator) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(item)
+//	}
+func Items[Item any, ResT any, RetT any](paginator Paginator[ResT, RetT]) Seq2[Item, error] {
+	return func(yield func(Item, error) bool) {
+		var zero Item
+		for paginator.Continue() {
+			if err := paginator.Next(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			page := paginator.Page()
+			items, ok := pageItems(page)
+			if !ok {
+				yield(zero, fmt.Errorf("cannot iterate over the items of page %T as it is not a slice/array or Itemable", page))
+				return
+			}
+
+			for _, item := range items {
+				typedItem, ok := item.(Item)
+				if !ok {
+					yield(zero, fmt.Errorf("item of type %T within page %T is not of type %T", item, page, zero))
+					return
+				}
+
+				if !yield(typedItem, nil) {
+					return
+				}
+			}
+		}
+	}
+}
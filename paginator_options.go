@@ -0,0 +1,151 @@
+package api
+
+import (
+	"time"
+)
+
+// paginatorOptions are the options that can be set for a Paginator using PaginatorOption(s).
+type paginatorOptions struct {
+	onPage     func(pageNo, pageLen, total int)
+	paramSet   paginatorParamSet
+	linkParser LinkParser
+	// pageTokenExtractor extracts the token of the next page from the current page when paramSet is tokenParamSet or
+	// pageInfoParamSet.
+	pageTokenExtractor func(page any) (token string, hasMore bool)
+	// initialAfter is the value of the "after" param for the first page. It is only used if initialAfterSet is true.
+	initialAfter     any
+	initialAfterSet  bool
+	omitInitialAfter bool
+	recordRequests   bool
+	limitParamNames  []string
+	requestTimeout   time.Duration
+	noThrottle       bool
+}
+
+// PaginatorOption is a functional option that configures a Paginator. PaginatorOption(s) can be passed to any of the
+// Paginator constructors (such as NewTypedPaginator and NewPaginator) within the args for the Binding. They will be
+// removed from the args before they are passed to the Binding.
+type PaginatorOption func(options *paginatorOptions)
+
+// splitPaginatorOptions separates any PaginatorOption(s) from the given args.
+func splitPaginatorOptions(args []any) (bindingArgs []any, options paginatorOptions) {
+	bindingArgs = make([]any, 0, len(args))
+	for _, arg := range args {
+		if option, ok := arg.(PaginatorOption); ok {
+			option(&options)
+			continue
+		}
+		bindingArgs = append(bindingArgs, arg)
+	}
+	return
+}
+
+// OnPage returns a PaginatorOption that sets a callback that is called each time Paginator.Next fetches a page. The
+// callback is passed the number of the page that was fetched (starting at 1), the length of that page, and the total
+// number of items that have been fetched so far. The callback is also called for empty pages so that the final state
+// of the Paginator can be observed.
+func OnPage(callback func(pageNo, pageLen, total int)) PaginatorOption {
+	return func(options *paginatorOptions) { options.onPage = callback }
+}
+
+// LinkHeaderPagination returns a PaginatorOption that makes the Paginator paginate using the Link headers of each
+// response, rather than using the "page"/"after" params of the Binding. After each page is fetched, the URL of the next
+// page is parsed from the http.Header returned by HeaderRecorder.LatestHeader using the given LinkParser. This URL then
+// replaces the entire URL of the HTTPRequest constructed by the Binding for the next page. Pagination finishes when
+// there is no next page. If the given LinkParser is nil, then ParseLinkHeader is used.
+//
+// The Client given to the Paginator must implement HeaderRecorder, and the Binding must construct a HTTPRequest.
+func LinkHeaderPagination(parser LinkParser) PaginatorOption {
+	return func(options *paginatorOptions) {
+		options.paramSet = linkHeaderParamSet
+		options.linkParser = parser
+	}
+}
+
+// PageTokenPagination returns a PaginatorOption that makes the Paginator paginate using opaque page tokens, such as
+// the "nextPageToken" returned by Google APIs. The Binding must have a "pageToken" BindingParam, whose default value is
+// used for the first page. After each page is fetched, the given extractor is called with the (untransformed) page to
+// find the token of the next page, which is then passed to the "pageToken" BindingParam. Pagination finishes when the
+// extractor returns false. RetT must be the return type of the Binding that is being paginated.
+func PageTokenPagination[RetT any](extractor func(page RetT) (token string, hasMore bool)) PaginatorOption {
+	return func(options *paginatorOptions) {
+		options.paramSet = tokenParamSet
+		options.pageTokenExtractor = func(page any) (string, bool) {
+			typedPage, ok := page.(RetT)
+			if !ok {
+				return "", false
+			}
+			return extractor(typedPage)
+		}
+	}
+}
+
+// PageInfoPagination returns a PaginatorOption that makes the Paginator paginate using the "pageInfo" of each page, as
+// in the GraphQL Relay connection pattern:
+//
+//	{ "items": [...], "pageInfo": { "hasNextPage": true, "endCursor": "..." } }
+//
+// The Binding must have an "after" BindingParam. After each page is fetched, the given extractor is called with the
+// (untransformed) page to find the end cursor and whether there is a next page. The end cursor is then passed to the
+// "after" BindingParam for the next page. Unlike the default "after" pagination, RetT does not need to implement
+// Afterable. The default value of the "after" BindingParam is used for the first page, unless InitialAfter is also
+// given. RetT must be the return type of the Binding that is being paginated.
+func PageInfoPagination[RetT any](extractor func(page RetT) (endCursor string, hasNext bool)) PaginatorOption {
+	return func(options *paginatorOptions) {
+		PageTokenPagination(extractor)(options)
+		options.paramSet = pageInfoParamSet
+	}
+}
+
+// InitialAfter returns a PaginatorOption that sets the value of the "after" param for the first page of a Paginator
+// that paginates using an "after" param. By default, the zero value of the "after" param's type is used for the first
+// page.
+func InitialAfter(value any) PaginatorOption {
+	return func(options *paginatorOptions) {
+		options.initialAfter = value
+		options.initialAfterSet = true
+		options.omitInitialAfter = false
+	}
+}
+
+// OmitInitialAfter returns a PaginatorOption that omits the value of the "after" param for the first page of a
+// Paginator that paginates using an "after" param. The default value of the "after" BindingParam will be passed to the
+// Binding instead of the zero value of its type, which allows the Binding to leave the "after" param out of the Request
+// so that the API can use its own default.
+func OmitInitialAfter() PaginatorOption {
+	return func(options *paginatorOptions) {
+		options.omitInitialAfter = true
+		options.initialAfterSet = false
+	}
+}
+
+// RecordRequests returns a PaginatorOption that makes the Paginator record the arguments that are passed to the Binding
+// for each page, after the paginator param values have been inserted. The recorded arguments can be fetched using
+// Paginator.RequestLog, which can be used to verify that the pages advanced correctly or to replay a crawl.
+func RecordRequests() PaginatorOption {
+	return func(options *paginatorOptions) { options.recordRequests = true }
+}
+
+// LimitParamNames returns a PaginatorOption that overrides the names of the BindingParam(s) that are checked for the
+// number of resources requested by each page. By default, these are "limit" and "count". This is used when the Client
+// is a RateLimitedClient that returns ResourceRateLimit(s), and is useful for APIs that use params such as "per_page" or
+// "pageSize". Paginator.SetLimitExtractor takes precedence over this option.
+func LimitParamNames(names ...string) PaginatorOption {
+	return func(options *paginatorOptions) { options.limitParamNames = names }
+}
+
+// RequestTimeout returns a PaginatorOption that applies a timeout to the context.Context of each execution of the
+// Binding by the Paginator. This is separate from the wait time between pages, any time spent sleeping for rate limits,
+// and the time budget given to Paginator.AllWithin, so it prevents a single hung page from stalling a crawl. A page that
+// times out returns an error that wraps context.DeadlineExceeded, which is sticky until Paginator.ClearErr is called.
+// The timeout is only enforced by Client(s) that respect the context.Context given to Client.Run.
+func RequestTimeout(d time.Duration) PaginatorOption {
+	return func(options *paginatorOptions) { options.requestTimeout = d }
+}
+
+// NoThrottle returns a PaginatorOption that disables the rate limit handling of the Paginator, in the same way as
+// creating the Paginator with a context.Context returned by WithoutThrottling. See WithoutThrottling for the risks of
+// ignoring the RateLimit(s) of a RateLimitedClient.
+func NoThrottle() PaginatorOption {
+	return func(options *paginatorOptions) { options.noThrottle = true }
+}
@@ -0,0 +1,1976 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/andygello555/gotils/v2/slices"
+	"github.com/pkg/errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// cappedPageClient returns a mockClient that serves the given number of items, where each page contains at most
+// pageCap items regardless of the "limit" argument that was requested.
+func cappedPageClient(items int, pageCap int) *mockClient {
+	return &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		args := req.(*mockRequest).args
+		page, limit := args[0].(int), args[1].(int)
+		if limit > pageCap {
+			limit = pageCap
+		}
+
+		response := make([]int, 0)
+		for i := (page - 1) * limit; i < page*limit && i < items; i++ {
+			response = append(response, i)
+		}
+		return response, nil
+	}}
+}
+
+func pagedIntBinding() Binding[[]int, []int] {
+	return NewBindingChain(mockRequestMethod[[]int, []int]).SetParamsMethod(func(binding Binding[[]int, []int]) []BindingParam {
+		return Params("page", 1, true, "limit", 10)
+	}).SetPaginated(true)
+}
+
+func TestPaginator_PageSize(t *testing.T) {
+	for testNo, test := range []struct {
+		items            int
+		pageCap          int
+		limit            int
+		expectedPageSize int
+		expectedRequests int
+	}{
+		{items: 8, pageCap: 3, limit: 10, expectedPageSize: 3, expectedRequests: 3},
+		{items: 9, pageCap: 3, limit: 10, expectedPageSize: 3, expectedRequests: 4},
+		{items: 7, pageCap: 10, limit: 4, expectedPageSize: 4, expectedRequests: 2},
+		{items: 8, pageCap: 10, limit: 4, expectedPageSize: 4, expectedRequests: 3},
+		{items: 0, pageCap: 3, limit: 10, expectedPageSize: 0, expectedRequests: 1},
+	} {
+		client := cappedPageClient(test.items, test.pageCap)
+		paginator, err := NewTypedPaginator(client, 0, pagedIntBinding(), test.limit)
+		if err != nil {
+			t.Fatalf("test no. %d could not create Paginator: %v", testNo+1, err)
+		}
+
+		var items []int
+		if items, err = paginator.All(); err != nil {
+			t.Errorf("test no. %d returned an error from All: %v", testNo+1, err)
+		}
+
+		expectedItems := make([]int, 0)
+		for i := 0; i < test.items; i++ {
+			expectedItems = append(expectedItems, i)
+		}
+
+		if len(items) != 0 || len(expectedItems) != 0 {
+			if !reflect.DeepEqual(items, expectedItems) {
+				t.Errorf("test no. %d expected items %v, not %v", testNo+1, expectedItems, items)
+			}
+		}
+
+		if paginator.PageSize() != test.expectedPageSize {
+			t.Errorf("test no. %d expected a page size of %d, not %d", testNo+1, test.expectedPageSize, paginator.PageSize())
+		}
+
+		if client.requests != test.expectedRequests {
+			t.Errorf("test no. %d expected %d requests, not %d", testNo+1, test.expectedRequests, client.requests)
+		}
+	}
+}
+
+func TestOnPage(t *testing.T) {
+	type pageEvent struct{ pageNo, pageLen, total int }
+	events := make([]pageEvent, 0)
+	paginator, err := NewTypedPaginator(cappedPageClient(5, 2), 0, pagedIntBinding(), 2, OnPage(func(pageNo, pageLen, total int) {
+		events = append(events, pageEvent{pageNo, pageLen, total})
+	}))
+	if err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	if _, err = paginator.All(); err != nil {
+		t.Fatalf("could not fetch all pages: %v", err)
+	}
+
+	expected := []pageEvent{{1, 2, 2}, {2, 2, 4}, {3, 1, 5}}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("expected OnPage events %v, not %v", expected, events)
+	}
+
+	// The callback should also be called for the final empty page
+	events = events[:0]
+	if paginator, err = NewTypedPaginator(cappedPageClient(4, 2), 0, pagedIntBinding(), 2, OnPage(func(pageNo, pageLen, total int) {
+		events = append(events, pageEvent{pageNo, pageLen, total})
+	})); err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	if _, err = paginator.All(); err != nil {
+		t.Fatalf("could not fetch all pages: %v", err)
+	}
+
+	expected = []pageEvent{{1, 2, 2}, {2, 2, 4}, {3, 0, 4}}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("expected OnPage events %v, not %v", expected, events)
+	}
+}
+
+func TestPaginator_Channel(t *testing.T) {
+	paginator, err := NewTypedPaginator(cappedPageClient(7, 2), 0, pagedIntBinding(), 2)
+	if err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	pages, errs, stop := paginator.Channel(1)
+	defer stop()
+	items := make([]int, 0)
+	pageCount := 0
+	for page := range pages {
+		items = append(items, page...)
+		pageCount++
+	}
+
+	if err = <-errs; err != nil {
+		t.Errorf("expected no error from Channel, got %v", err)
+	}
+
+	if expected := []int{0, 1, 2, 3, 4, 5, 6}; !reflect.DeepEqual(items, expected) {
+		t.Errorf("expected items %v, not %v", expected, items)
+	}
+
+	if pageCount != 4 {
+		t.Errorf("expected 4 pages, not %d", pageCount)
+	}
+
+	// Cancelling the context should stop the goroutine even if the consumer stops reading
+	ctx, cancel := context.WithCancel(context.Background())
+	if paginator, err = NewTypedPaginatorCtx(ctx, cappedPageClient(100, 1), 0, pagedIntBinding(), 1); err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	pages, errs, stop = paginator.Channel(0)
+	defer stop()
+	<-pages
+	cancel()
+
+	select {
+	case err = <-errs:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected error to be context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("goroutine did not stop after the context was cancelled")
+	}
+}
+
+func TestPaginator_ChannelStop(t *testing.T) {
+	// The Paginator uses context.Background, so the goroutine can only be stopped using the stop function
+	paginator, err := NewTypedPaginator(cappedPageClient(100, 1), 0, pagedIntBinding(), 1)
+	if err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	// Abandon the page channel after reading a single page
+	pages, errs, stop := paginator.Channel(0)
+	<-pages
+	stop()
+
+	select {
+	case err = <-errs:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected error to be context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("goroutine did not stop after calling stop")
+	}
+
+	// Both channels are closed by the goroutine when it exits
+	if _, ok := <-errs; ok {
+		t.Errorf("expected the error channel to be closed after calling stop")
+	}
+
+	if _, ok := <-pages; ok {
+		t.Errorf("expected the page channel to be closed after calling stop")
+	}
+
+	// The Paginator's context.Context is restored once the goroutine exits, so it can be used again
+	if err = paginator.Next(); err != nil {
+		t.Errorf("expected no error from Next after the goroutine exited, got %v", err)
+	}
+}
+
+// headerRecordingClient is a httpClient that implements HeaderRecorder.
+type headerRecordingClient struct {
+	httpClient
+	headers *sync.Map
+}
+
+func (h headerRecordingClient) Run(ctx context.Context, bindingName string, attrs map[string]any, req Request, res any) (err error) {
+	request := req.(HTTPRequest).Request
+
+	var response *http.Response
+	if response, err = http.DefaultClient.Do(request); err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	h.headers.Store(bindingName, response.Header)
+	return json.NewDecoder(response.Body).Decode(res)
+}
+
+func (h headerRecordingClient) LatestHeader(bindingName string) http.Header {
+	if header, ok := h.headers.Load(bindingName); ok {
+		return header.(http.Header)
+	}
+	return nil
+}
+
+func TestPaginator_NextWaitTimeCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	paginator, err := NewTypedPaginatorCtx(ctx, cappedPageClient(100, 1), time.Hour, pagedIntBinding(), 1)
+	if err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	// The wait time is only waited between pages, so the first page is fetched immediately
+	if err = paginator.Next(); err != nil {
+		t.Fatalf("could not fetch the first page: %v", err)
+	}
+
+	time.AfterFunc(50*time.Millisecond, cancel)
+	start := time.Now()
+	if err = paginator.Next(); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected error to be context.Canceled, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected Next to return promptly after the context was cancelled, took %s", elapsed)
+	}
+}
+
+func TestParseLinkHeader(t *testing.T) {
+	for testNo, test := range []struct {
+		links        []string
+		expectedNext string
+		expectedOk   bool
+	}{
+		{[]string{`<https://example.com/items?page=2>; rel="next", <https://example.com/items?page=5>; rel="last"`}, "https://example.com/items?page=2", true},
+		{[]string{`<https://example.com/items?page=1>; rel="prev"`, `</items?page=3>; rel="next"`}, "/items?page=3", true},
+		{[]string{`<https://example.com/items?page=1>; rel="first prev"`}, "", false},
+		{nil, "", false},
+	} {
+		header := make(http.Header)
+		for _, link := range test.links {
+			header.Add("Link", link)
+		}
+
+		next, ok := ParseLinkHeader(header)
+		if next != test.expectedNext || ok != test.expectedOk {
+			t.Errorf(
+				"test no. %d expected (%q, %t), not (%q, %t)",
+				testNo+1, test.expectedNext, test.expectedOk, next, ok,
+			)
+		}
+	}
+}
+
+func TestLinkHeaderPagination(t *testing.T) {
+	const pages = 3
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+		if page < pages-1 {
+			w.Header().Set("Link", fmt.Sprintf(`</items?cursor=%d>; rel="next"`, page+1))
+		}
+		_ = json.NewEncoder(w).Encode([]int{page * 2, page*2 + 1})
+	}))
+	defer server.Close()
+
+	binding := NewRESTBinding[[]int, []int](http.MethodGet, server.URL+"/items", func(binding Binding[[]int, []int]) []BindingParam {
+		return Params()
+	}, true)
+
+	if _, err := NewTypedPaginator(httpClient{}, 0, binding, LinkHeaderPagination(nil)); err == nil {
+		t.Errorf("expected an error when creating a Link header Paginator for a Client that is not a HeaderRecorder")
+	}
+
+	paginator, err := NewTypedPaginator(headerRecordingClient{headers: &sync.Map{}}, 0, binding, LinkHeaderPagination(nil))
+	if err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	var items []int
+	if items, err = paginator.All(); err != nil {
+		t.Fatalf("could not fetch all pages: %v", err)
+	}
+
+	if expected := []int{0, 1, 2, 3, 4, 5}; !reflect.DeepEqual(items, expected) {
+		t.Errorf("expected items %v, not %v", expected, items)
+	}
+}
+
+func TestPaginator_UntilOlderThan(t *testing.T) {
+	type event struct {
+		ID   int       `json:"id"`
+		Time time.Time `json:"time"`
+	}
+
+	// Events are returned from newest to oldest, with each event being an hour older than the last
+	now := time.Now().UTC().Truncate(time.Second)
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		args := req.(*mockRequest).args
+		page, limit := args[0].(int), args[1].(int)
+		events := make([]event, 0)
+		for i := (page - 1) * limit; i < page*limit && i < 10; i++ {
+			events = append(events, event{ID: i, Time: now.Add(-time.Duration(i) * time.Hour)})
+		}
+		return events, nil
+	}}
+
+	binding := NewBindingChain(mockRequestMethod[[]event, []event]).SetParamsMethod(func(binding Binding[[]event, []event]) []BindingParam {
+		return Params("page", 1, true, "limit", 10)
+	}).SetPaginated(true)
+
+	paginator, err := NewTypedPaginator(client, 0, binding, 3)
+	if err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	var events []event
+	if events, err = paginator.UntilOlderThan(now.Add(-4*time.Hour-30*time.Minute), func(item any) time.Time {
+		return item.(event).Time
+	}); err != nil {
+		t.Fatalf("could not fetch pages until cutoff: %v", err)
+	}
+
+	ids := slices.Comprehension(events, func(idx int, value event, arr []event) int { return value.ID })
+	if expected := []int{0, 1, 2, 3, 4}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("expected events %v, not %v", expected, ids)
+	}
+
+	if client.requests != 2 {
+		t.Errorf("expected 2 requests to be made, not %d", client.requests)
+	}
+}
+
+// cursorPage is a page of items that is paginated using an "after" cursor.
+type cursorPage struct {
+	Items []int  `json:"items"`
+	Next  string `json:"next"`
+}
+
+func (c *cursorPage) After() any { return c.Next }
+
+func (c *cursorPage) Merge(similar any) error {
+	c.Items = append(c.Items, similar.(*cursorPage).Items...)
+	c.Next = similar.(*cursorPage).Next
+	return nil
+}
+
+func (c *cursorPage) HasMore() bool { return c.Next != "" }
+
+func TestInitialAfter(t *testing.T) {
+	// The client serves 6 items, 2 per page. The cursor "start" is the server-side default cursor, whereas an empty
+	// cursor is invalid.
+	const items, limit = 6, 2
+	var afters []string
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		after := req.(*mockRequest).args[0].(string)
+		afters = append(afters, after)
+
+		var start int
+		switch {
+		case after == "start":
+		case strings.HasPrefix(after, "c"):
+			start, _ = strconv.Atoi(strings.TrimPrefix(after, "c"))
+		default:
+			return nil, fmt.Errorf("invalid cursor %q", after)
+		}
+
+		page := cursorPage{Items: make([]int, 0)}
+		for i := start; i < start+limit && i < items; i++ {
+			page.Items = append(page.Items, i)
+		}
+
+		if start+limit < items {
+			page.Next = fmt.Sprintf("c%d", start+limit)
+		}
+		return page, nil
+	}}
+
+	binding := NewBindingChain(mockRequestMethod[*cursorPage, *cursorPage]).SetParamsMethod(func(binding Binding[*cursorPage, *cursorPage]) []BindingParam {
+		return Params("after", "start")
+	}).SetPaginated(true)
+
+	for testNo, test := range []struct {
+		option         PaginatorOption
+		expectedAfters []string
+		expectedItems  []int
+		expectedErr    bool
+	}{
+		{
+			expectedAfters: []string{""},
+			expectedErr:    true,
+		},
+		{
+			option:         InitialAfter("c2"),
+			expectedAfters: []string{"c2", "c4"},
+			expectedItems:  []int{2, 3, 4, 5},
+		},
+		{
+			option:         OmitInitialAfter(),
+			expectedAfters: []string{"start", "c2", "c4"},
+			expectedItems:  []int{0, 1, 2, 3, 4, 5},
+		},
+	} {
+		afters = nil
+		args := make([]any, 0)
+		if test.option != nil {
+			args = append(args, test.option)
+		}
+
+		paginator, err := NewTypedPaginator(client, 0, binding, args...)
+		if err != nil {
+			t.Fatalf("test no. %d could not create Paginator: %v", testNo+1, err)
+		}
+
+		var pages *cursorPage
+		pages, err = paginator.All()
+		if test.expectedErr != (err != nil) {
+			t.Errorf("test no. %d expected error to be %t, got %v", testNo+1, test.expectedErr, err)
+		}
+
+		if !reflect.DeepEqual(afters, test.expectedAfters) {
+			t.Errorf("test no. %d expected afters %q, not %q", testNo+1, test.expectedAfters, afters)
+		}
+
+		if !test.expectedErr && !reflect.DeepEqual(pages.Items, test.expectedItems) {
+			t.Errorf("test no. %d expected items %v, not %v", testNo+1, test.expectedItems, pages.Items)
+		}
+	}
+}
+
+func TestPaginator_AllReversed(t *testing.T) {
+	for testNo, test := range []struct {
+		items    int
+		expected []int
+	}{
+		{7, []int{6, 5, 4, 3, 2, 1, 0}},
+		{1, []int{0}},
+		{0, nil},
+	} {
+		paginator, err := NewTypedPaginator(cappedPageClient(test.items, 2), 0, pagedIntBinding(), 2)
+		if err != nil {
+			t.Fatalf("test no. %d could not create Paginator: %v", testNo+1, err)
+		}
+
+		var items []int
+		if items, err = paginator.AllReversed(); err != nil {
+			t.Errorf("test no. %d could not fetch all pages: %v", testNo+1, err)
+		} else if len(items) != len(test.expected) || (len(items) > 0 && !reflect.DeepEqual(items, test.expected)) {
+			t.Errorf("test no. %d expected items %v, not %v", testNo+1, test.expected, items)
+		}
+	}
+
+	// Untyped Paginators should also reverse their pages
+	paginator, err := NewPaginator(cappedPageClient(5, 2), 0, WrapBinding(pagedIntBinding()), 2)
+	if err != nil {
+		t.Fatalf("could not create untyped Paginator: %v", err)
+	}
+
+	var items any
+	if items, err = paginator.AllReversed(); err != nil {
+		t.Errorf("could not fetch all pages for untyped Paginator: %v", err)
+	} else if expected := []int{4, 3, 2, 1, 0}; !reflect.DeepEqual(items, expected) {
+		t.Errorf("expected items %v from untyped Paginator, not %v", expected, items)
+	}
+}
+
+func TestPaginator_ParamSet(t *testing.T) {
+	afterBinding := NewBindingChain(mockRequestMethod[*cursorPage, *cursorPage]).SetParamsMethod(func(binding Binding[*cursorPage, *cursorPage]) []BindingParam {
+		return Params("after", "")
+	}).SetPaginated(true)
+
+	for testNo, test := range []struct {
+		paginator func() (Paginator[any, any], error)
+		expected  string
+	}{
+		{
+			paginator: func() (Paginator[any, any], error) {
+				return NewPaginator(cappedPageClient(0, 1), 0, WrapBinding(pagedIntBinding()))
+			},
+			expected: "page",
+		},
+		{
+			paginator: func() (Paginator[any, any], error) {
+				return NewPaginator(&mockClient{}, 0, WrapBinding(afterBinding))
+			},
+			expected: "after",
+		},
+		{
+			paginator: func() (Paginator[any, any], error) {
+				return NewPaginator(headerRecordingClient{headers: &sync.Map{}}, 0, WrapBinding(pagedIntBinding()), LinkHeaderPagination(nil))
+			},
+			expected: "link",
+		},
+		{
+			paginator: func() (Paginator[any, any], error) {
+				return NewPaginator(&mockClient{}, 0, WrapBinding(afterBinding), PageInfoPagination(func(page *cursorPage) (string, bool) {
+					return page.Next, page.Next != ""
+				}))
+			},
+			expected: "pageInfo",
+		},
+	} {
+		paginator, err := test.paginator()
+		if err != nil {
+			t.Errorf("test no. %d could not create Paginator: %v", testNo+1, err)
+			continue
+		}
+
+		if actual := paginator.ParamSet(); actual != test.expected {
+			t.Errorf("test no. %d expected param set %q, not %q", testNo+1, test.expected, actual)
+		}
+	}
+}
+
+func TestPaginator_ContinueNilPage(t *testing.T) {
+	pag, err := NewPaginator(cappedPageClient(3, 2), 0, WrapBinding(pagedIntBinding()), 2)
+	if err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	// The initial page of an untyped Paginator is a nil interface
+	p := pag.(*typedPaginator[any, any])
+	if p.currentPage != nil {
+		t.Fatalf("expected the initial page to be nil, not %v", p.currentPage)
+	}
+
+	if !pag.Continue() {
+		t.Errorf("expected Continue to return true before the first page has been fetched")
+	}
+
+	var items any
+	if items, err = pag.All(); err != nil {
+		t.Fatalf("could not fetch all pages: %v", err)
+	} else if expected := []int{0, 1, 2}; !reflect.DeepEqual(items, expected) {
+		t.Errorf("expected items %v, not %v", expected, items)
+	}
+
+	// A nil page after the first page has been fetched should stop pagination rather than panicking
+	p.currentPage = nil
+	if pag.Continue() {
+		t.Errorf("expected Continue to return false for a nil page after the first page")
+	}
+}
+
+func TestPaginator_SetPageTransform(t *testing.T) {
+	type score struct {
+		ID    int `json:"id"`
+		Value int `json:"value"`
+	}
+
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		args := req.(*mockRequest).args
+		page, limit := args[0].(int), args[1].(int)
+		scores := make([]score, 0)
+		for i := (page - 1) * limit; i < page*limit && i < 5; i++ {
+			scores = append(scores, score{ID: i, Value: i * 10})
+		}
+		return scores, nil
+	}}
+
+	binding := NewBindingChain(mockRequestMethod[[]score, []score]).SetParamsMethod(func(binding Binding[[]score, []score]) []BindingParam {
+		return Params("page", 1, true, "limit", 10)
+	}).SetPaginated(true)
+
+	double := func(page []score) ([]score, error) {
+		doubled := make([]score, len(page))
+		for i, s := range page {
+			doubled[i] = score{ID: s.ID, Value: s.Value * 2}
+		}
+		return doubled, nil
+	}
+
+	paginator, err := NewTypedPaginator(client, 0, binding, 2)
+	if err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	var scores []score
+	if scores, err = paginator.SetPageTransform(double).All(); err != nil {
+		t.Fatalf("could not fetch all pages: %v", err)
+	}
+
+	expected := []score{{0, 0}, {1, 20}, {2, 40}, {3, 60}, {4, 80}}
+	if !reflect.DeepEqual(scores, expected) {
+		t.Errorf("expected scores %v, not %v", expected, scores)
+	}
+
+	// An error from the transform should abort fetching pages
+	if paginator, err = NewTypedPaginator(client, 0, binding, 2); err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	if _, err = paginator.SetPageTransform(func(page []score) ([]score, error) {
+		if page[0].ID == 2 {
+			return page, errors.New("lookup failed")
+		}
+		return page, nil
+	}).All(); err == nil || err.Error() != "could not transform page no. 2: lookup failed" {
+		t.Errorf("expected transform error for page no. 2, got %v", err)
+	}
+}
+
+func TestPaginator_SetLimitExtractor(t *testing.T) {
+	type query struct {
+		Search string
+		Limit  int
+	}
+
+	newClient := func() *mockRateLimitedClient {
+		client := &mockRateLimitedClient{mockClient: &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+			args := req.(*mockRequest).args
+			page, limit := args[0].(int), args[1].(query).Limit
+			items := make([]int, 0)
+			for i := (page - 1) * limit; i < page*limit && i < 5; i++ {
+				items = append(items, i)
+			}
+			return items, nil
+		}}}
+
+		// Only 3 resources remain, which is fewer than the limit of 5 nested within the query argument
+		client.AddRateLimit("search", mockRateLimit{
+			reset:     time.Now().UTC().Add(50 * time.Millisecond),
+			remaining: 3,
+			t:         ResourceRateLimit,
+		})
+		return client
+	}
+
+	binding := NewBindingChain(mockRequestMethod[[]int, []int]).SetParamsMethod(func(binding Binding[[]int, []int]) []BindingParam {
+		return Params("page", 1, true, "query", query{})
+	}).SetPaginated(true).SetName("search")
+
+	for testNo, test := range []struct {
+		extractor    LimitExtractor
+		expectedLogs int
+	}{
+		// The default LimitExtractor cannot find the limit, so the Paginator should not wait for the rate limit to reset
+		{nil, 0},
+		{func(params []BindingParam, args []any) (float64, bool) {
+			if q, ok := args[1].(query); ok {
+				return float64(q.Limit), true
+			}
+			return 0, false
+		}, 1},
+	} {
+		client := newClient()
+		paginator, err := NewTypedPaginator(client, 0, binding, query{Search: "gapi", Limit: 5})
+		if err != nil {
+			t.Fatalf("test no. %d could not create Paginator: %v", testNo+1, err)
+		}
+
+		var items []int
+		if items, err = paginator.SetLimitExtractor(test.extractor).Pages(1); err != nil {
+			t.Fatalf("test no. %d could not fetch first page: %v", testNo+1, err)
+		}
+
+		if expected := []int{0, 1, 2, 3, 4}; !reflect.DeepEqual(items, expected) {
+			t.Errorf("test no. %d expected items %v, not %v", testNo+1, expected, items)
+		}
+
+		if len(client.logs) != test.expectedLogs {
+			t.Errorf("test no. %d expected %d log(s) about waiting for the rate limit, got %v", testNo+1, test.expectedLogs, client.logs)
+		}
+	}
+}
+
+func TestRecordRequests(t *testing.T) {
+	paginator, err := NewTypedPaginator(cappedPageClient(5, 2), 0, pagedIntBinding(), 2, RecordRequests())
+	if err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	if _, err = paginator.All(); err != nil {
+		t.Fatalf("could not fetch all pages: %v", err)
+	}
+
+	if expected := [][]any{{1, 2}, {2, 2}, {3, 2}}; !reflect.DeepEqual(paginator.RequestLog(), expected) {
+		t.Errorf("expected request log %v, not %v", expected, paginator.RequestLog())
+	}
+
+	// Requests should not be logged without the RecordRequests option
+	if paginator, err = NewTypedPaginator(cappedPageClient(5, 2), 0, pagedIntBinding(), 2); err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	if _, err = paginator.All(); err != nil {
+		t.Fatalf("could not fetch all pages: %v", err)
+	}
+
+	if log := paginator.RequestLog(); log != nil {
+		t.Errorf("expected no request log, not %v", log)
+	}
+}
+
+func TestPaginator_AllWithin(t *testing.T) {
+	slowClient := func(items int, delay time.Duration) *mockClient {
+		client := cappedPageClient(items, 2)
+		run := client.run
+		client.run = func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+			time.Sleep(delay)
+			return run(ctx, bindingName, attrs, req)
+		}
+		return client
+	}
+
+	for testNo, test := range []struct {
+		client        *mockClient
+		budget        time.Duration
+		expectedItems []int
+		expectedErr   error
+	}{
+		// Each page takes 30ms, so only 2 pages should be fetched within the 50ms budget
+		{slowClient(10, 30*time.Millisecond), 50 * time.Millisecond, []int{0, 1, 2, 3}, ErrTimeBudgetExceeded},
+		{slowClient(3, 0), time.Second, []int{0, 1, 2}, nil},
+	} {
+		paginator, err := NewTypedPaginator(test.client, 0, pagedIntBinding(), 2)
+		if err != nil {
+			t.Fatalf("test no. %d could not create Paginator: %v", testNo+1, err)
+		}
+
+		items, err := paginator.AllWithin(test.budget)
+		if err != test.expectedErr {
+			t.Errorf("test no. %d expected error %v, not %v", testNo+1, test.expectedErr, err)
+		}
+
+		if !reflect.DeepEqual(items, test.expectedItems) {
+			t.Errorf("test no. %d expected items %v, not %v", testNo+1, test.expectedItems, items)
+		}
+	}
+}
+
+func TestLimitParamNames(t *testing.T) {
+	newClient := func() *mockRateLimitedClient {
+		client := &mockRateLimitedClient{mockClient: &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+			args := req.(*mockRequest).args
+			page, perPage := args[0].(int), args[1].(int)
+			items := make([]int, 0)
+			for i := (page - 1) * perPage; i < page*perPage && i < 5; i++ {
+				items = append(items, i)
+			}
+			return items, nil
+		}}}
+
+		// Only 3 resources remain, which is fewer than the 5 requested by the "per_page" param
+		client.AddRateLimit("list", mockRateLimit{
+			reset:     time.Now().UTC().Add(50 * time.Millisecond),
+			remaining: 3,
+			t:         ResourceRateLimit,
+		})
+		return client
+	}
+
+	binding := NewBindingChain(mockRequestMethod[[]int, []int]).SetParamsMethod(func(binding Binding[[]int, []int]) []BindingParam {
+		return Params("page", 1, true, "per_page", 10)
+	}).SetPaginated(true).SetName("list")
+
+	for testNo, test := range []struct {
+		options      []any
+		expectedLogs int
+	}{
+		// "per_page" is not one of the default limit param names, so the Paginator should not wait
+		{[]any{5}, 0},
+		{[]any{5, LimitParamNames("per_page", "pageSize")}, 1},
+	} {
+		client := newClient()
+		paginator, err := NewTypedPaginator(client, 0, binding, test.options...)
+		if err != nil {
+			t.Fatalf("test no. %d could not create Paginator: %v", testNo+1, err)
+		}
+
+		var items []int
+		if items, err = paginator.Pages(1); err != nil {
+			t.Fatalf("test no. %d could not fetch first page: %v", testNo+1, err)
+		}
+
+		if expected := []int{0, 1, 2, 3, 4}; !reflect.DeepEqual(items, expected) {
+			t.Errorf("test no. %d expected items %v, not %v", testNo+1, expected, items)
+		}
+
+		if len(client.logs) != test.expectedLogs {
+			t.Errorf("test no. %d expected %d log(s) about waiting for the rate limit, got %v", testNo+1, test.expectedLogs, client.logs)
+		}
+	}
+}
+
+func TestNewPaginatorFromState(t *testing.T) {
+	t.Run("page", func(t *testing.T) {
+		client := cappedPageClient(5, 2)
+		paginator, err := NewTypedPaginator(client, 0, pagedIntBinding(), 2)
+		if err != nil {
+			t.Fatalf("could not create Paginator: %v", err)
+		}
+
+		var items []int
+		if items, err = paginator.Pages(1); err != nil {
+			t.Fatalf("could not fetch first page: %v", err)
+		} else if expected := []int{0, 1}; !reflect.DeepEqual(items, expected) {
+			t.Errorf("expected items %v before checkpoint, not %v", expected, items)
+		}
+
+		var state []byte
+		if state, err = paginator.State(); err != nil {
+			t.Fatalf("could not get Paginator state: %v", err)
+		}
+
+		if paginator, err = NewPaginatorFromState(client, pagedIntBinding(), state, 2, RecordRequests()); err != nil {
+			t.Fatalf("could not resume Paginator from state %s: %v", state, err)
+		}
+
+		if paginator.PageSize() != 2 {
+			t.Errorf("expected resumed page size to be 2, not %d", paginator.PageSize())
+		}
+
+		if items, err = paginator.All(); err != nil {
+			t.Fatalf("could not fetch remaining pages: %v", err)
+		} else if expected := []int{2, 3, 4}; !reflect.DeepEqual(items, expected) {
+			t.Errorf("expected items %v after resuming, not %v", expected, items)
+		}
+
+		if expected := [][]any{{2, 2}, {3, 2}}; !reflect.DeepEqual(paginator.RequestLog(), expected) {
+			t.Errorf("expected request log %v after resuming, not %v", expected, paginator.RequestLog())
+		}
+
+		// A finished Paginator should resume as finished
+		if state, err = paginator.State(); err != nil {
+			t.Fatalf("could not get finished Paginator state: %v", err)
+		}
+
+		if paginator, err = NewPaginatorFromState(client, pagedIntBinding(), state, 2); err != nil {
+			t.Fatalf("could not resume finished Paginator from state %s: %v", state, err)
+		} else if paginator.Continue() {
+			t.Errorf("expected finished Paginator to not continue after resuming")
+		}
+	})
+
+	t.Run("after", func(t *testing.T) {
+		var afters []string
+		client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+			after := req.(*mockRequest).args[0].(string)
+			afters = append(afters, after)
+			start, _ := strconv.Atoi(strings.TrimPrefix(after, "c"))
+			page := cursorPage{Items: []int{start, start + 1}}
+			if start+2 < 6 {
+				page.Next = fmt.Sprintf("c%d", start+2)
+			}
+			return page, nil
+		}}
+
+		binding := NewBindingChain(mockRequestMethod[*cursorPage, *cursorPage]).SetParamsMethod(func(binding Binding[*cursorPage, *cursorPage]) []BindingParam {
+			return Params("after", "c0")
+		}).SetPaginated(true)
+
+		paginator, err := NewTypedPaginator(client, 0, binding, OmitInitialAfter())
+		if err != nil {
+			t.Fatalf("could not create Paginator: %v", err)
+		}
+
+		if _, err = paginator.Pages(1); err != nil {
+			t.Fatalf("could not fetch first page: %v", err)
+		}
+
+		var state []byte
+		if state, err = paginator.State(); err != nil {
+			t.Fatalf("could not get Paginator state: %v", err)
+		}
+
+		// The state is serialisable, so it can be resumed by a Paginator for a different Binding instance
+		if paginator, err = NewPaginatorFromState(client, binding, state, OmitInitialAfter()); err != nil {
+			t.Fatalf("could not resume Paginator from state %s: %v", state, err)
+		}
+
+		var pages *cursorPage
+		if pages, err = paginator.All(); err != nil {
+			t.Fatalf("could not fetch remaining pages: %v", err)
+		}
+
+		if expected := []int{2, 3, 4, 5}; !reflect.DeepEqual(pages.Items, expected) {
+			t.Errorf("expected items %v after resuming, not %v", expected, pages.Items)
+		}
+
+		if expected := []string{"c0", "c2", "c4"}; !reflect.DeepEqual(afters, expected) {
+			t.Errorf("expected afters %q, not %q", expected, afters)
+		}
+
+		// States cannot be resumed by a Paginator that paginates using a different set of params
+		if _, err = NewPaginatorFromState(cappedPageClient(5, 2), pagedIntBinding(), state, 2); err == nil {
+			t.Errorf("expected an error when resuming an \"after\" state with a \"page\" Paginator")
+		}
+
+		// ...or that uses the same params in a different way
+		pageInfo := PageInfoPagination(func(page *cursorPage) (string, bool) { return page.Next, page.Next != "" })
+		if _, err = NewPaginatorFromState(client, binding, state, pageInfo); err == nil {
+			t.Errorf("expected an error when resuming an \"after\" state with a \"pageInfo\" Paginator")
+		}
+
+		if paginator, err = NewTypedPaginator(client, 0, binding, pageInfo); err != nil {
+			t.Fatalf("could not create \"pageInfo\" Paginator: %v", err)
+		}
+
+		if _, err = paginator.Pages(1); err != nil {
+			t.Fatalf("could not fetch first page: %v", err)
+		}
+
+		if state, err = paginator.State(); err != nil {
+			t.Fatalf("could not get \"pageInfo\" Paginator state: %v", err)
+		}
+
+		if _, err = NewPaginatorFromState(client, binding, state, OmitInitialAfter()); err == nil {
+			t.Errorf("expected an error when resuming a \"pageInfo\" state with an \"after\" Paginator")
+		}
+	})
+}
+
+func TestPaginator_UntilReason(t *testing.T) {
+	failingClient := cappedPageClient(10, 2)
+	run := failingClient.run
+	failingClient.run = func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		if req.(*mockRequest).args[0].(int) == 3 {
+			return nil, fmt.Errorf("page 3 is broken")
+		}
+		return run(ctx, bindingName, attrs, req)
+	}
+
+	for testNo, test := range []struct {
+		client         *mockClient
+		predicate      func(paginator Paginator[[]int, []int], pages []int) bool
+		expectedItems  []int
+		expectedReason StopReason
+		expectedErr    bool
+	}{
+		{
+			client:         cappedPageClient(5, 2),
+			predicate:      func(paginator Paginator[[]int, []int], pages []int) bool { return true },
+			expectedItems:  []int{0, 1, 2, 3, 4},
+			expectedReason: StopExhausted,
+		},
+		{
+			client:         cappedPageClient(10, 2),
+			predicate:      func(paginator Paginator[[]int, []int], pages []int) bool { return len(pages) < 4 },
+			expectedItems:  []int{0, 1, 2, 3},
+			expectedReason: StopPredicate,
+		},
+		{
+			client:         failingClient,
+			predicate:      func(paginator Paginator[[]int, []int], pages []int) bool { return true },
+			expectedItems:  []int{0, 1, 2, 3},
+			expectedReason: StopError,
+			expectedErr:    true,
+		},
+	} {
+		paginator, err := NewTypedPaginator(test.client, 0, pagedIntBinding(), 2)
+		if err != nil {
+			t.Fatalf("test no. %d could not create Paginator: %v", testNo+1, err)
+		}
+
+		items, reason, err := paginator.UntilReason(test.predicate)
+		if test.expectedErr != (err != nil) {
+			t.Errorf("test no. %d expected error to be %t, got %v", testNo+1, test.expectedErr, err)
+		}
+
+		if reason != test.expectedReason {
+			t.Errorf("test no. %d expected stop reason %v, not %v", testNo+1, test.expectedReason, reason)
+		}
+
+		if !reflect.DeepEqual(items, test.expectedItems) {
+			t.Errorf("test no. %d expected items %v, not %v", testNo+1, test.expectedItems, items)
+		}
+	}
+
+	// Untyped Paginators should also return the stop reason
+	paginator, err := NewPaginator(cappedPageClient(10, 2), 0, WrapBinding(pagedIntBinding()), 2)
+	if err != nil {
+		t.Fatalf("could not create untyped Paginator: %v", err)
+	}
+
+	if _, reason, err := paginator.UntilReason(func(paginator Paginator[any, any], pages any) bool {
+		return paginator.Page() == nil
+	}); err != nil || reason != StopPredicate {
+		t.Errorf("expected untyped Paginator to stop due to the predicate, got %v (%v)", reason, err)
+	}
+}
+
+func TestAppendPage(t *testing.T) {
+	expected := make([]int, 0)
+	pages := reflect.ValueOf([]int(nil))
+	for page := 0; page < 50; page++ {
+		items := make([]int, page%7)
+		for i := range items {
+			items[i] = page*10 + i
+		}
+		expected = append(expected, items...)
+		pages = appendPage(pages, reflect.ValueOf(items))
+	}
+
+	if actual := pages.Interface().([]int); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, not %v", expected, actual)
+	}
+}
+
+func BenchmarkAppendPage(b *testing.B) {
+	page := reflect.ValueOf(make([]int, 100))
+	for _, benchmark := range []struct {
+		name   string
+		append func(pages reflect.Value, page reflect.Value) reflect.Value
+	}{
+		{"AppendSlice", reflect.AppendSlice},
+		{"AppendPage", appendPage},
+	} {
+		b.Run(benchmark.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				pages := reflect.ValueOf([]int(nil))
+				for j := 0; j < 1000; j++ {
+					pages = benchmark.append(pages, page)
+				}
+			}
+		})
+	}
+}
+
+func TestPaginator_LastWrapper(t *testing.T) {
+	type wrapper struct {
+		Items []int `json:"items"`
+		Total int   `json:"total"`
+	}
+
+	const total = 5
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		args := req.(*mockRequest).args
+		page, limit := args[0].(int), args[1].(int)
+		response := wrapper{Items: make([]int, 0), Total: total}
+		for i := (page - 1) * limit; i < page*limit && i < total; i++ {
+			response.Items = append(response.Items, i)
+		}
+		return response, nil
+	}}
+
+	binding := NewUnwrappingBinding(
+		mockRequestMethod[[]int, []int],
+		func(w wrapper) []int { return w.Items },
+		func(binding Binding[[]int, []int]) []BindingParam { return Params("page", 1, true, "limit", 2) },
+		true,
+	)
+
+	paginator, err := NewTypedPaginator(client, 0, binding, 1)
+	if err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	if paginator.LastWrapper().IsValid() {
+		t.Errorf("expected LastWrapper to be invalid before the first page is fetched")
+	}
+
+	if err = paginator.Next(); err != nil {
+		t.Fatalf("could not fetch the first page: %v", err)
+	}
+
+	w, ok := paginator.LastWrapper().Interface().(*wrapper)
+	switch {
+	case !ok:
+		t.Errorf("expected LastWrapper to be a *wrapper, not %s", paginator.LastWrapper().Type())
+	case w.Total != total:
+		t.Errorf("expected the total count from LastWrapper to be %d, not %d", total, w.Total)
+	case !reflect.DeepEqual(w.Items, paginator.Page()):
+		t.Errorf("expected LastWrapper items %v to equal the current page %v", w.Items, paginator.Page())
+	}
+}
+
+func TestPaginator_UntilFound(t *testing.T) {
+	for testNo, test := range []struct {
+		target   int
+		expected []int
+		requests int
+	}{
+		{target: 0, expected: []int{0, 1, 2}, requests: 1},
+		{target: 7, expected: []int{0, 1, 2, 3, 4, 5, 6, 7, 8}, requests: 3},
+		{target: 100, expected: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, requests: 4},
+	} {
+		client := cappedPageClient(10, 3)
+		paginator, err := NewTypedPaginator(client, 0, pagedIntBinding(), 3)
+		if err != nil {
+			t.Fatalf("test no. %d could not create Paginator: %v", testNo+1, err)
+		}
+
+		actual, err := paginator.UntilFound(func(item any) bool { return item.(int) == test.target })
+		switch {
+		case err != nil:
+			t.Errorf("test no. %d could not fetch pages until found: %v", testNo+1, err)
+		case !reflect.DeepEqual(actual, test.expected):
+			t.Errorf("test no. %d expected %v, not %v", testNo+1, test.expected, actual)
+		case client.requests != test.requests:
+			t.Errorf("test no. %d expected %d requests, not %d", testNo+1, test.requests, client.requests)
+		}
+	}
+
+	// Mergeable return types that do not implement Itemable cannot be searched
+	binding := NewBindingChain(mockRequestMethod[*cursorPage, *cursorPage]).SetParamsMethod(func(binding Binding[*cursorPage, *cursorPage]) []BindingParam {
+		return Params("after", "")
+	}).SetPaginated(true)
+	paginator, err := NewTypedPaginator(&mockClient{}, 0, binding)
+	if err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	if _, err = paginator.UntilFound(func(item any) bool { return true }); err == nil {
+		t.Errorf("expected an error when the return type is not Itemable")
+	}
+}
+
+func TestPaginator_SetMerger(t *testing.T) {
+	type item struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		page := req.(*mockRequest).args[0].(int)
+		items := make([]item, 0)
+		for i := (page - 1) * 2; i < page*2 && i < 5; i++ {
+			items = append(items, item{ID: i, Name: fmt.Sprintf("item %d", i)})
+		}
+		return items, nil
+	}}
+
+	binding := NewBindingChain(mockRequestMethod[[]item, []item]).SetParamsMethod(func(binding Binding[[]item, []item]) []BindingParam {
+		return Params("page", 1, true, "limit", 2)
+	}).SetPaginated(true)
+
+	paginator, err := NewTypedPaginator(client, 0, binding, 1)
+	if err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	var aggregates []any
+	pages, err := paginator.SetMerger(func(aggregate any, page any) (any, error) {
+		aggregates = append(aggregates, aggregate)
+		if aggregate == nil {
+			aggregate = make(map[int]item)
+		}
+
+		byID := aggregate.(map[int]item)
+		for _, i := range page.([]item) {
+			byID[i.ID] = i
+		}
+		return byID, nil
+	}).All()
+	if err != nil {
+		t.Fatalf("could not fetch all pages: %v", err)
+	}
+
+	if pages != nil {
+		t.Errorf("expected All to return the zero value when the aggregate is not of type %T, not %v", pages, pages)
+	}
+
+	if aggregates[0] != nil {
+		t.Errorf("expected the first aggregate passed to the merger to be nil, not %v", aggregates[0])
+	}
+
+	expected := map[int]item{
+		0: {0, "item 0"}, 1: {1, "item 1"}, 2: {2, "item 2"}, 3: {3, "item 3"}, 4: {4, "item 4"},
+	}
+	if !reflect.DeepEqual(paginator.Aggregate(), expected) {
+		t.Errorf("expected aggregate %v, not %v", expected, paginator.Aggregate())
+	}
+}
+
+func TestPaginator_AllPartialOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The context is cancelled once the second of five pages has been fetched
+	client := cappedPageClient(10, 2)
+	run := client.run
+	client.run = func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		if client.requests == 2 {
+			cancel()
+		}
+		return run(ctx, bindingName, attrs, req)
+	}
+
+	paginator, err := NewTypedPaginatorCtx(ctx, client, 10*time.Millisecond, pagedIntBinding(), 2)
+	if err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	pages, err := paginator.All()
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected error to be context.Canceled, got %v", err)
+	}
+
+	if expected := []int{0, 1, 2, 3}; !reflect.DeepEqual(pages, expected) {
+		t.Errorf("expected the first two pages %v to be returned, not %v", expected, pages)
+	}
+}
+
+func TestPaginator_Peek(t *testing.T) {
+	client := cappedPageClient(5, 2)
+	paginator, err := NewTypedPaginator(client, 0, pagedIntBinding(), 2)
+	if err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if peeked, err := paginator.Peek(); err != nil {
+			t.Fatalf("could not peek the first page: %v", err)
+		} else if expected := []int{0, 1}; !reflect.DeepEqual(peeked, expected) {
+			t.Errorf("expected peeked page %v, not %v", expected, peeked)
+		}
+	}
+
+	if paginator.Page() != nil {
+		t.Errorf("expected Peek not to advance the current page, got %v", paginator.Page())
+	}
+
+	if err = paginator.Next(); err != nil {
+		t.Fatalf("could not fetch the first page: %v", err)
+	}
+
+	if client.requests != 1 {
+		t.Errorf("expected Peek followed by Next to make 1 request, not %d", client.requests)
+	}
+
+	if expected := []int{0, 1}; !reflect.DeepEqual(paginator.Page(), expected) {
+		t.Errorf("expected page %v after Next, not %v", expected, paginator.Page())
+	}
+
+	// The rest of the pages are aggregated as normal after peeking
+	if _, err = paginator.Peek(); err != nil {
+		t.Fatalf("could not peek the second page: %v", err)
+	}
+
+	pages, err := paginator.All()
+	if err != nil {
+		t.Fatalf("could not fetch all pages: %v", err)
+	}
+
+	if expected := []int{2, 3, 4}; !reflect.DeepEqual(pages, expected) {
+		t.Errorf("expected the remaining pages %v, not %v", expected, pages)
+	}
+
+	if client.requests != 3 {
+		t.Errorf("expected 3 requests in total, not %d", client.requests)
+	}
+
+	// An error whilst peeking becomes the sticky error, which is returned by Peek and Next until it is cleared
+	client = cappedPageClient(5, 2)
+	run := client.run
+	client.run = func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		if client.requests == 1 {
+			return nil, fmt.Errorf("transient error")
+		}
+		return run(ctx, bindingName, attrs, req)
+	}
+
+	if paginator, err = NewTypedPaginator(client, 0, pagedIntBinding(), 2); err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	if _, err = paginator.Peek(); err == nil {
+		t.Fatalf("expected Peek to return the error of the first request")
+	} else if paginator.Err() != err {
+		t.Errorf("expected the error from Peek to be the sticky error, not %v", paginator.Err())
+	}
+
+	if _, peekErr := paginator.Peek(); peekErr != err {
+		t.Errorf("expected Peek to return the sticky error %v, not %v", err, peekErr)
+	}
+
+	if nextErr := paginator.Next(); nextErr != err {
+		t.Errorf("expected Next to return the sticky error %v, not %v", err, nextErr)
+	}
+
+	if client.requests != 1 {
+		t.Errorf("expected the sticky error to prevent any more requests, not %d requests", client.requests)
+	}
+
+	paginator.ClearErr()
+	if peeked, err := paginator.Peek(); err != nil {
+		t.Errorf("could not peek the first page after clearing the error: %v", err)
+	} else if expected := []int{0, 1}; !reflect.DeepEqual(peeked, expected) {
+		t.Errorf("expected peeked page %v after clearing the error, not %v", expected, peeked)
+	}
+}
+
+func TestItems(t *testing.T) {
+	paginator, err := NewTypedPaginator(cappedPageClient(7, 2), 0, pagedIntBinding(), 2)
+	if err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	items := make([]int, 0)
+	Items[int](paginator)(func(item int, err error) bool {
+		if err != nil {
+			t.Fatalf("could not iterate over items: %v", err)
+		}
+		items = append(items, item)
+		return true
+	})
+
+	if expected := []int{0, 1, 2, 3, 4, 5, 6}; !reflect.DeepEqual(items, expected) {
+		t.Errorf("expected %d items %v, not %d items %v", len(expected), expected, len(items), items)
+	}
+
+	// Iteration can be stopped early, and items that are not of type Item produce an error
+	if paginator, err = NewTypedPaginator(cappedPageClient(7, 2), 0, pagedIntBinding(), 2); err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	count := 0
+	Items[int](paginator)(func(item int, err error) bool {
+		count++
+		return count < 3
+	})
+
+	if count != 3 {
+		t.Errorf("expected iteration to stop after 3 items, not %d", count)
+	}
+
+	var iterErr error
+	Items[string](paginator)(func(item string, err error) bool {
+		iterErr = err
+		return err == nil
+	})
+
+	if iterErr == nil {
+		t.Errorf("expected an error when iterating over items of the wrong type")
+	}
+}
+
+func TestPaginator_ClearErr(t *testing.T) {
+	client := cappedPageClient(5, 2)
+	run, fail := client.run, true
+	client.run = func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		if req.(*mockRequest).args[0].(int) == 2 && fail {
+			fail = false
+			return nil, errors.New("transient error")
+		}
+		return run(ctx, bindingName, attrs, req)
+	}
+
+	paginator, err := NewTypedPaginator(client, 0, pagedIntBinding(), 2)
+	if err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	if err = paginator.Next(); err != nil {
+		t.Fatalf("could not fetch the first page: %v", err)
+	}
+
+	if err = paginator.Next(); err == nil {
+		t.Fatalf("expected the second page to return an error")
+	}
+
+	// The error is sticky, so no more requests are made until it is cleared
+	if paginator.Continue() || paginator.Next() != err || paginator.Err() != err {
+		t.Errorf("expected the error %v to be sticky", err)
+	}
+
+	if client.requests != 2 {
+		t.Errorf("expected 2 requests before the error is cleared, not %d", client.requests)
+	}
+
+	if expected := []int{0, 1}; !reflect.DeepEqual(paginator.Page(), expected) {
+		t.Errorf("expected the current page to still be %v, not %v", expected, paginator.Page())
+	}
+
+	paginator.ClearErr()
+	if paginator.Err() != nil || !paginator.Continue() {
+		t.Errorf("expected the Paginator to be able to continue after ClearErr")
+	}
+
+	pages, err := paginator.All()
+	if err != nil {
+		t.Fatalf("could not fetch the remaining pages after ClearErr: %v", err)
+	}
+
+	if expected := []int{2, 3, 4}; !reflect.DeepEqual(pages, expected) {
+		t.Errorf("expected the remaining pages %v, not %v", expected, pages)
+	}
+}
+
+// pagedInts is a response that reports its own pagination metadata by implementing Paged.
+type pagedInts struct {
+	Values []int `json:"values"`
+	Page   int   `json:"page"`
+	Pages  int   `json:"pages"`
+}
+
+func (p pagedInts) TotalPages() int  { return p.Pages }
+func (p pagedInts) CurrentPage() int { return p.Page }
+
+func TestPaginator_Paged(t *testing.T) {
+	const items, limit = 6, 2
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		page := req.(*mockRequest).args[0].(int)
+		response := pagedInts{Values: make([]int, 0), Page: page, Pages: items / limit}
+		for i := (page - 1) * limit; i < page*limit && i < items; i++ {
+			response.Values = append(response.Values, i)
+		}
+		return response, nil
+	}}
+
+	binding := NewBindingChain(mockRequestMethod[pagedInts, []int]).SetParamsMethod(func(binding Binding[pagedInts, []int]) []BindingParam {
+		return Params("page", 1, true, "limit", 10)
+	}).SetResponseMethod(func(binding Binding[pagedInts, []int], response pagedInts, args ...any) []int {
+		return response.Values
+	}).SetPaginated(true)
+
+	paginator, err := NewTypedPaginator(client, 0, binding, limit)
+	if err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	pages, err := paginator.All()
+	if err != nil {
+		t.Fatalf("could not fetch all pages: %v", err)
+	}
+
+	if expected := []int{0, 1, 2, 3, 4, 5}; !reflect.DeepEqual(pages, expected) {
+		t.Errorf("expected pages %v, not %v", expected, pages)
+	}
+
+	// Without Paged, a full last page would require an extra request to find an empty page
+	if client.requests != items/limit {
+		t.Errorf("expected %d requests, not %d", items/limit, client.requests)
+	}
+}
+
+func TestPaginator_Requests(t *testing.T) {
+	client := cappedPageClient(5, 2)
+	run, fail := client.run, true
+	client.run = func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		if req.(*mockRequest).args[0].(int) == 2 && fail {
+			fail = false
+			return nil, errors.New("transient error")
+		}
+		return run(ctx, bindingName, attrs, req)
+	}
+
+	paginator, err := NewTypedPaginator(client, 0, pagedIntBinding(), 2)
+	if err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	pageCount := 0
+	for paginator.Continue() || paginator.Err() != nil {
+		if paginator.Err() != nil {
+			paginator.ClearErr()
+		}
+
+		if err = paginator.Next(); err == nil {
+			pageCount++
+		}
+	}
+
+	if pageCount != 3 {
+		t.Errorf("expected 3 pages, not %d", pageCount)
+	}
+
+	if paginator.Requests() != 4 {
+		t.Errorf("expected the retried page to bring the number of requests to 4, not %d", paginator.Requests())
+	}
+}
+
+// tokenPage is a page of items that contains the token of the next page, like the responses of Google APIs.
+type tokenPage struct {
+	Items         []int  `json:"items"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+func (tp *tokenPage) Merge(similar any) error {
+	tp.Items = append(tp.Items, similar.(*tokenPage).Items...)
+	return nil
+}
+
+func (tp *tokenPage) HasMore() bool { return tp.NextPageToken != "" }
+
+func TestPageTokenPagination(t *testing.T) {
+	pages := map[string]tokenPage{
+		"":       {Items: []int{0, 1}, NextPageToken: "CAIQAA"},
+		"CAIQAA": {Items: []int{2, 3}, NextPageToken: "CAQQAA"},
+		"CAQQAA": {Items: []int{4}},
+	}
+
+	var tokens []string
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		token := req.(*mockRequest).args[0].(string)
+		tokens = append(tokens, token)
+		return pages[token], nil
+	}}
+
+	binding := NewBindingChain(mockRequestMethod[*tokenPage, *tokenPage]).SetParamsMethod(func(binding Binding[*tokenPage, *tokenPage]) []BindingParam {
+		return Params("pageToken", "", "pageSize", 2)
+	}).SetPaginated(true)
+
+	paginator, err := NewTypedPaginator(client, 0, binding, PageTokenPagination(func(page *tokenPage) (string, bool) {
+		return page.NextPageToken, page.NextPageToken != ""
+	}))
+	if err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	if paginator.ParamSet() != "pageToken" {
+		t.Errorf("expected ParamSet to be \"pageToken\", not %q", paginator.ParamSet())
+	}
+
+	all, err := paginator.All()
+	if err != nil {
+		t.Fatalf("could not fetch all pages: %v", err)
+	}
+
+	if expected := []int{0, 1, 2, 3, 4}; !reflect.DeepEqual(all.Items, expected) {
+		t.Errorf("expected items %v, not %v", expected, all.Items)
+	}
+
+	if expected := []string{"", "CAIQAA", "CAQQAA"}; !reflect.DeepEqual(tokens, expected) {
+		t.Errorf("expected page tokens %v, not %v", expected, tokens)
+	}
+
+	// A Binding without a "pageToken" param cannot be paginated using page tokens
+	if _, err = NewTypedPaginator(client, 0, pagedIntBinding(), PageTokenPagination(func(page []int) (string, bool) {
+		return "", false
+	})); err == nil {
+		t.Errorf("expected an error for a Binding without a \"pageToken\" param")
+	}
+}
+
+func TestNewPaginator_Until(t *testing.T) {
+	client := cappedPageClient(10, 2)
+	paginator, err := NewPaginator(client, 0, WrapBinding(pagedIntBinding()), 2)
+	if err != nil {
+		t.Fatalf("could not create untyped Paginator: %v", err)
+	}
+
+	var predicate func(paginator Paginator[any, any], pages any) bool = func(paginator Paginator[any, any], pages any) bool {
+		return len(pages.([]int)) < 5
+	}
+
+	pages, err := paginator.Until(predicate)
+	if err != nil {
+		t.Fatalf("could not fetch pages until predicate is false: %v", err)
+	}
+
+	if expected := []int{0, 1, 2, 3, 4, 5}; !reflect.DeepEqual(pages, expected) {
+		t.Errorf("expected pages %v, not %v", expected, pages)
+	}
+
+	if client.requests != 3 {
+		t.Errorf("expected Until to stop after 3 requests, not %d", client.requests)
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	client := cappedPageClient(6, 2)
+	run, hang := client.run, true
+	client.run = func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		if req.(*mockRequest).args[0].(int) == 2 && hang {
+			hang = false
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return run(ctx, bindingName, attrs, req)
+	}
+
+	paginator, err := NewTypedPaginator(client, 0, pagedIntBinding(), 2, RequestTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	items := make([]int, 0)
+	timeouts := 0
+	start := time.Now()
+	for paginator.Continue() || paginator.Err() != nil {
+		if err = paginator.Err(); err != nil {
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("expected the hung page to time out, got %v", err)
+			}
+			timeouts++
+			paginator.ClearErr()
+		}
+
+		if err = paginator.Next(); err == nil {
+			items = append(items, paginator.Page()...)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the hung page to be timed out promptly, took %s", elapsed)
+	}
+
+	if timeouts != 1 {
+		t.Errorf("expected 1 page to time out, not %d", timeouts)
+	}
+
+	if expected := []int{0, 1, 2, 3, 4, 5}; !reflect.DeepEqual(items, expected) {
+		t.Errorf("expected the crawl to continue after the timeout and fetch %v, not %v", expected, items)
+	}
+}
+
+// relayConnection is a page of items that follows the GraphQL Relay connection pattern.
+type relayConnection struct {
+	Items    []string `json:"items"`
+	PageInfo struct {
+		HasNextPage bool   `json:"hasNextPage"`
+		EndCursor   string `json:"endCursor"`
+	} `json:"pageInfo"`
+}
+
+func (rc *relayConnection) Merge(similar any) error {
+	rc.Items = append(rc.Items, similar.(*relayConnection).Items...)
+	return nil
+}
+
+func (rc *relayConnection) HasMore() bool { return rc.PageInfo.HasNextPage }
+
+func TestPageInfoPagination(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e"}
+	var cursors []string
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		args := req.(*mockRequest).args
+		after, first := args[0].(string), args[1].(int)
+		cursors = append(cursors, after)
+
+		start := 0
+		if after != "" {
+			start, _ = strconv.Atoi(strings.TrimPrefix(after, "cursor:"))
+		}
+
+		end := start + first
+		if end > len(items) {
+			end = len(items)
+		}
+
+		var connection relayConnection
+		connection.Items = items[start:end]
+		connection.PageInfo.HasNextPage = end < len(items)
+		connection.PageInfo.EndCursor = fmt.Sprintf("cursor:%d", end)
+		return connection, nil
+	}}
+
+	binding := NewBindingChain(mockRequestMethod[*relayConnection, *relayConnection]).SetParamsMethod(func(binding Binding[*relayConnection, *relayConnection]) []BindingParam {
+		return Params("after", "", "first", 2)
+	}).SetPaginated(true)
+
+	paginator, err := NewTypedPaginator(client, 0, binding, 2, PageInfoPagination(func(page *relayConnection) (string, bool) {
+		return page.PageInfo.EndCursor, page.PageInfo.HasNextPage
+	}))
+	if err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	connection, err := paginator.All()
+	if err != nil {
+		t.Fatalf("could not fetch all pages: %v", err)
+	}
+
+	if !reflect.DeepEqual(connection.Items, items) {
+		t.Errorf("expected items %v, not %v", items, connection.Items)
+	}
+
+	if expected := []string{"", "cursor:2", "cursor:4"}; !reflect.DeepEqual(cursors, expected) {
+		t.Errorf("expected cursors %v, not %v", expected, cursors)
+	}
+}
+
+func TestPaginator_SetEmptyDetector(t *testing.T) {
+	const sentinel = -1
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		// The API always returns a page of 2 items, and pads pages past the end with sentinel items
+		page := req.(*mockRequest).args[0].(int)
+		response := []int{sentinel, sentinel}
+		for i := 0; i < 2 && (page-1)*2+i < 4; i++ {
+			response[i] = (page-1)*2 + i
+		}
+		return response, nil
+	}}
+
+	paginator, err := NewTypedPaginator(client, 0, pagedIntBinding(), 2)
+	if err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	pages, err := paginator.SetEmptyDetector(func(page []int) bool {
+		return len(page) > 0 && page[0] == sentinel
+	}).All()
+	if err != nil {
+		t.Fatalf("could not fetch all pages: %v", err)
+	}
+
+	if expected := []int{0, 1, 2, 3}; !reflect.DeepEqual(pages, expected) {
+		t.Errorf("expected pages %v without the sentinel page, not %v", expected, pages)
+	}
+
+	if client.requests != 3 {
+		t.Errorf("expected pagination to stop after the sentinel page (3 requests), not %d requests", client.requests)
+	}
+}
+
+func TestPaginator_WritePages(t *testing.T) {
+	paginator, err := NewTypedPaginator(cappedPageClient(5, 2), 0, pagedIntBinding(), 2)
+	if err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	var buf bytes.Buffer
+	written, err := paginator.WritePages(&buf, func(page []int) ([]byte, error) {
+		var b []byte
+		for _, item := range page {
+			b = append(b, strconv.Itoa(item)+"\n"...)
+		}
+		return b, nil
+	})
+	if err != nil {
+		t.Fatalf("could not write pages: %v", err)
+	}
+
+	if expected := "0\n1\n2\n3\n4\n"; buf.String() != expected {
+		t.Errorf("expected the pages to be written as %q, not %q", expected, buf.String())
+	}
+
+	if written != int64(buf.Len()) {
+		t.Errorf("expected %d bytes to be written, not %d", buf.Len(), written)
+	}
+
+	if paginator, err = NewTypedPaginator(cappedPageClient(5, 2), 0, pagedIntBinding(), 2); err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	buf.Reset()
+	encodeErr := errors.New("encode failed")
+	if written, err = paginator.WritePages(&buf, func(page []int) ([]byte, error) {
+		if page[0] == 2 {
+			return nil, encodeErr
+		}
+		return []byte("page\n"), nil
+	}); !errors.Is(err, encodeErr) {
+		t.Errorf("expected the encoding error to be returned, not %v", err)
+	}
+
+	if written != 5 || buf.String() != "page\n" {
+		t.Errorf("expected only the first page to be written (5 bytes), not %d bytes: %q", written, buf.String())
+	}
+}
+
+func TestBindingProto_SetPaginatorParamSet(t *testing.T) {
+	binding := NewBindingChain(mockRequestMethod[[]int, []int]).SetParamsMethod(func(binding Binding[[]int, []int]) []BindingParam {
+		return Params("p", 1, true, "limit", 10)
+	}).SetPaginated(true)
+
+	if _, err := NewTypedPaginator(cappedPageClient(5, 2), 0, binding, 2); err == nil {
+		t.Fatalf("expected an error when creating a Paginator for a Binding without a detectable page param")
+	}
+
+	binding = binding.SetPaginatorParamSet(PaginatorParamConfig{Page: "p"})
+	paginator, err := NewTypedPaginator(cappedPageClient(5, 2), 0, binding, 2)
+	if err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	if paginator.ParamSet() != "page" {
+		t.Errorf("expected Paginator to paginate using \"page\", not %q", paginator.ParamSet())
+	}
+
+	pages, err := paginator.All()
+	if err != nil {
+		t.Fatalf("could not fetch all pages: %v", err)
+	}
+
+	if expected := []int{0, 1, 2, 3, 4}; !reflect.DeepEqual(pages, expected) {
+		t.Errorf("expected pages %v, not %v", expected, pages)
+	}
+
+	// The PaginatorParamConfig should also be used by the un-typed Paginator
+	untyped, err := NewPaginator(cappedPageClient(5, 2), 0, WrapBinding(binding), 2)
+	if err != nil {
+		t.Fatalf("could not create un-typed Paginator: %v", err)
+	}
+
+	if all, err := untyped.All(); err != nil || !reflect.DeepEqual(all, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("expected un-typed Paginator to fetch all pages, got %v (err: %v)", all, err)
+	}
+
+	for testNo, config := range []PaginatorParamConfig{
+		{Page: "missing"},
+		{Page: "p", After: "p"},
+	} {
+		if _, err = NewTypedPaginator(cappedPageClient(5, 2), 0, binding.SetPaginatorParamSet(config), 2); err == nil {
+			t.Errorf("test no. %d: expected an error for PaginatorParamConfig %+v", testNo+1, config)
+		}
+	}
+}
+
+func TestWithoutThrottling(t *testing.T) {
+	binding := pagedIntBinding().SetName("ints")
+	for testNo, test := range []struct {
+		ctx  context.Context
+		args []any
+	}{
+		{ctx: WithoutThrottling(context.Background()), args: []any{2}},
+		{ctx: context.Background(), args: []any{2, NoThrottle()}},
+	} {
+		client := &mockRateLimitedClient{mockClient: cappedPageClient(5, 2)}
+		// The rate limit has been used up and does not reset for another hour
+		client.AddRateLimit("ints", mockRateLimit{
+			reset:     time.Now().UTC().Add(time.Hour),
+			remaining: 0,
+			t:         RequestRateLimit,
+		})
+
+		paginator, err := NewTypedPaginatorCtx(test.ctx, client, 0, binding, test.args...)
+		if err != nil {
+			t.Fatalf("test no. %d could not create Paginator: %v", testNo+1, err)
+		}
+
+		start := time.Now()
+		pages, err := paginator.All()
+		if err != nil {
+			t.Fatalf("test no. %d could not fetch all pages: %v", testNo+1, err)
+		}
+
+		if elapsed := time.Since(start); elapsed >= time.Second {
+			t.Errorf("test no. %d expected the Paginator not to sleep for the rate limit, but it took %s", testNo+1, elapsed)
+		}
+
+		if expected := []int{0, 1, 2, 3, 4}; !reflect.DeepEqual(pages, expected) {
+			t.Errorf("test no. %d expected pages %v, not %v", testNo+1, expected, pages)
+		}
+
+		if len(client.logs) != 0 {
+			t.Errorf("test no. %d expected no rate limit logs, not %v", testNo+1, client.logs)
+		}
+	}
+
+	if ThrottlingDisabled(context.Background()) {
+		t.Errorf("expected throttling to be enabled for a context that was not returned by WithoutThrottling")
+	}
+}
+
+func TestPaginator_StateAfterError(t *testing.T) {
+	var (
+		afters []string
+		failed bool
+	)
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		after := req.(*mockRequest).args[0].(string)
+		afters = append(afters, after)
+		// The second page fails the first time that it is requested
+		if after == "c2" && !failed {
+			failed = true
+			return nil, errors.New("transient failure")
+		}
+
+		start, _ := strconv.Atoi(strings.TrimPrefix(after, "c"))
+		page := cursorPage{Items: []int{start, start + 1}}
+		if start+2 < 6 {
+			page.Next = fmt.Sprintf("c%d", start+2)
+		}
+		return page, nil
+	}}
+
+	binding := NewBindingChain(mockRequestMethod[*cursorPage, *cursorPage]).SetParamsMethod(func(binding Binding[*cursorPage, *cursorPage]) []BindingParam {
+		return Params("after", "c0")
+	}).SetPaginated(true)
+
+	paginator, err := NewTypedPaginator(client, 0, binding, OmitInitialAfter())
+	if err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	if _, err = paginator.All(); err == nil {
+		t.Fatalf("expected the second page to fail")
+	}
+
+	var state []byte
+	if state, err = paginator.State(); err != nil {
+		t.Fatalf("could not get Paginator state: %v", err)
+	}
+
+	if paginator, err = NewPaginatorFromState(client, binding, state, OmitInitialAfter()); err != nil {
+		t.Fatalf("could not resume Paginator from state %s: %v", state, err)
+	}
+
+	if !paginator.Continue() {
+		t.Fatalf("expected the Paginator resumed from state %s to continue", state)
+	}
+
+	var pages *cursorPage
+	if pages, err = paginator.All(); err != nil {
+		t.Fatalf("could not fetch remaining pages: %v", err)
+	}
+
+	if expected := []int{2, 3, 4, 5}; !reflect.DeepEqual(pages.Items, expected) {
+		t.Errorf("expected items %v after resuming, not %v", expected, pages.Items)
+	}
+
+	if expected := []string{"c0", "c2", "c2", "c4"}; !reflect.DeepEqual(afters, expected) {
+		t.Errorf("expected afters %q, not %q", expected, afters)
+	}
+}
+
+// filteredPage is a cursorPage that also reports its length, as the pages of an API that filters its results after
+// paging them would.
+type filteredPage struct {
+	cursorPage
+}
+
+func (f *filteredPage) After() any { return f.Next }
+
+func (f *filteredPage) Merge(similar any) error {
+	return f.cursorPage.Merge(&similar.(*filteredPage).cursorPage)
+}
+
+func (f *filteredPage) HasMore() bool { return f.Next != "" }
+
+func (f *filteredPage) Len() int { return len(f.Items) }
+
+func TestPaginator_ShortCursorPage(t *testing.T) {
+	// The cursor-based API filters its results after paging, so the second page is shorter than the first even though
+	// there are more pages after it
+	pages := map[string]filteredPage{
+		"":   {cursorPage{Items: []int{0, 1, 2}, Next: "c1"}},
+		"c1": {cursorPage{Items: []int{3}, Next: "c2"}},
+		"c2": {cursorPage{Items: []int{4, 5, 6}}},
+	}
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		return pages[req.(*mockRequest).args[0].(string)], nil
+	}}
+
+	binding := NewBindingChain(mockRequestMethod[*filteredPage, *filteredPage]).SetParamsMethod(func(binding Binding[*filteredPage, *filteredPage]) []BindingParam {
+		return Params("after", "")
+	}).SetPaginated(true)
+
+	paginator, err := NewTypedPaginator(client, 0, binding)
+	if err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	all, err := paginator.All()
+	if err != nil {
+		t.Fatalf("could not fetch all pages: %v", err)
+	}
+
+	if expected := []int{0, 1, 2, 3, 4, 5, 6}; !reflect.DeepEqual(all.Items, expected) {
+		t.Errorf("expected items %v, not %v", expected, all.Items)
+	}
+}
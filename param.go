@@ -1,8 +1,12 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 	"reflect"
+	"sort"
+	"strings"
 )
 
 // BindingParam represents a param for a Binding. Binding.Execute uses BindingParam(s) for type-checking the arguments
@@ -29,6 +33,14 @@ type BindingParam struct {
 	t reflect.Type
 	// interfaceFlag is set when the type denoted by t is an interface.
 	interfaceFlag bool
+	// secret is set when the argument for this BindingParam should not be logged. See BindingParam.Secret.
+	secret bool
+	// mapSchema is the reflect.Type of each key within a map[string]any argument. See BindingParam.MapSchema.
+	mapSchema map[string]reflect.Type
+	// doc is the human-readable description of the BindingParam. See BindingParam.Doc.
+	doc string
+	// env is the name of the environment variable that overrides defaultValue when it is set. See BindingParam.FromEnv.
+	env string
 }
 
 func getReflectType(a any) (reflect.Type, bool, any) {
@@ -63,9 +75,27 @@ func getReflectType(a any) (reflect.Type, bool, any) {
 	}
 }
 
+// ParamError is the error returned when an argument cannot be matched to a BindingParam. For instance, when a required
+// BindingParam was not provided, or when the type of the argument does not match the type of the BindingParam.
+type ParamError struct {
+	// Param is the name of the BindingParam that the error occurred for.
+	Param string
+	// No is the index of the BindingParam/argument that the error occurred for.
+	No  int
+	err error
+}
+
+func paramErrorf(param string, no int, format string, args ...any) *ParamError {
+	return &ParamError{Param: param, No: no, err: fmt.Errorf(format, args...)}
+}
+
+func (pe *ParamError) Error() string { return pe.err.Error() }
+
+func (pe *ParamError) Unwrap() error { return pe.err }
+
 // String returns the string representation of the BindingParam in the format:
 //
-//	<name>: ["[I]" if interface]<type>["?" if !required]["..." if variadic][" = <defaultValue>" if !required]
+//	<name>: ["[I]" if interface]<type>["?" if !required]["..." if variadic][" = <defaultValue>" if !required][" // <doc>" if doc]
 func (bp BindingParam) String() string {
 	required := ""
 	def := ""
@@ -77,6 +107,10 @@ func (bp BindingParam) String() string {
 		default:
 			def = fmt.Sprintf(" = %v", bp.defaultValue)
 		}
+
+		if bp.secret {
+			def = fmt.Sprintf(" = %v", redacted{})
+		}
 	}
 
 	i := ""
@@ -88,7 +122,168 @@ func (bp BindingParam) String() string {
 	if bp.variadic {
 		variadic = "..."
 	}
-	return fmt.Sprintf("%s: %s%v%s%s%s", bp.name, i, bp.Type(), required, variadic, def)
+	doc := ""
+	if bp.doc != "" {
+		doc = " // " + bp.doc
+	}
+	return fmt.Sprintf("%s: %s%v%s%s%s%s", bp.name, i, bp.Type(), required, variadic, def, doc)
+}
+
+// MarshalJSON marshals the BindingParam to a JSON object containing its name, type, whether it is required/variadic,
+// its default value (if it is not required), and its doc (if it has one). The default value of a secret BindingParam
+// is redacted.
+func (bp BindingParam) MarshalJSON() ([]byte, error) {
+	type bindingParamJSON struct {
+		Name     string `json:"name"`
+		Type     string `json:"type"`
+		Required bool   `json:"required"`
+		Variadic bool   `json:"variadic"`
+		Default  any    `json:"default,omitempty"`
+		Doc      string `json:"doc,omitempty"`
+	}
+
+	out := bindingParamJSON{
+		Name:     bp.name,
+		Required: bp.required,
+		Variadic: bp.variadic,
+		Doc:      bp.doc,
+	}
+
+	if bp.t != nil {
+		out.Type = bp.t.String()
+	}
+
+	if !bp.required {
+		out.Default = bp.defaultValue
+		if bp.secret {
+			out.Default = redacted{}.String()
+		}
+	}
+	return json.Marshal(out)
+}
+
+// Doc returns a copy of the BindingParam with the given human-readable description. The description is included in
+// the output of BindingParam.String and BindingParam.MarshalJSON. For example:
+//
+//	Param("limit", 10).Doc("The maximum number of items to return")
+func (bp BindingParam) Doc(desc string) BindingParam {
+	bp.doc = desc
+	return bp
+}
+
+// Description returns the description of the BindingParam that was set using BindingParam.Doc.
+func (bp BindingParam) Description() string { return bp.doc }
+
+// FromEnv returns a copy of the BindingParam whose default value is read from the environment variable of the given
+// name, when it is set. The value of the environment variable is parsed into the type of the BindingParam in the same
+// way as Binding.ArgsFromStrings. If the environment variable is not set, then the static default value is used. The
+// environment variable is read each time the default value is required by Binding.TypeCheckArgs. For example:
+//
+//	Param("limit", 10).FromEnv("API_PAGE_SIZE")
+func (bp BindingParam) FromEnv(name string) BindingParam {
+	bp.env = name
+	return bp
+}
+
+// defaultArg returns the default value of the BindingParam. This is the parsed value of the environment variable set
+// by BindingParam.FromEnv, if it is set, otherwise it is the static default value.
+func (bp BindingParam) defaultArg() (any, error) {
+	if bp.env != "" {
+		if value, ok := os.LookupEnv(bp.env); ok {
+			arg, err := parseArg(bp.Type(), value)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse environment variable %s=%q for param %q: %w", bp.env, value, bp.name, err)
+			}
+			return arg, nil
+		}
+	}
+	return bp.defaultValue, nil
+}
+
+// Secret returns a copy of the BindingParam that is marked as secret. The arguments for secret BindingParam(s), such as
+// API keys and tokens, will be redacted wherever arguments are logged. For example:
+//
+//	ReqParam("token", "").Secret()
+func (bp BindingParam) Secret() BindingParam {
+	bp.secret = true
+	return bp
+}
+
+// IsSecret returns whether the BindingParam was marked as secret using BindingParam.Secret.
+func (bp BindingParam) IsSecret() bool { return bp.secret }
+
+// redacted is the type of the placeholder that is used in place of secret arguments when they are logged.
+type redacted struct{}
+
+func (redacted) String() string { return "****" }
+
+// redactArgs returns a copy of the given arguments where each argument for a secret BindingParam is replaced by the
+// "****" placeholder. This should be used wherever arguments are logged.
+func redactArgs(params []BindingParam, args []any) []any {
+	redactedArgs := make([]any, len(args))
+	copy(redactedArgs, args)
+	for i := range redactedArgs {
+		var param BindingParam
+		switch {
+		case i < len(params):
+			param = params[i]
+		case len(params) > 0 && params[len(params)-1].variadic:
+			param = params[len(params)-1]
+		default:
+			continue
+		}
+
+		if param.secret {
+			redactedArgs[i] = redacted{}
+		}
+	}
+	return redactedArgs
+}
+
+// MapSchema returns a copy of the BindingParam that validates the values within map[string]any arguments against the
+// given schema when type-checking. Each key within the argument must exist within the schema, and the value for that key
+// must be of the reflect.Type in the schema (or implement it, if it is an interface). Keys within the schema can be
+// omitted from the argument. For example:
+//
+//	Param("columnValues", map[string]any{}).MapSchema(map[string]reflect.Type{
+//		"status": reflect.TypeOf(""),
+//		"date":   reflect.TypeOf(time.Time{}),
+//	})
+func (bp BindingParam) MapSchema(schema map[string]reflect.Type) BindingParam {
+	bp.mapSchema = schema
+	return bp
+}
+
+// checkMapSchema checks the given argument against the schema set by BindingParam.MapSchema. If no schema is set or
+// the argument is not a map[string]any, then no error is returned.
+func (bp BindingParam) checkMapSchema(arg any) error {
+	m, ok := arg.(map[string]any)
+	if bp.mapSchema == nil || !ok {
+		return nil
+	}
+
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		t, ok := bp.mapSchema[key]
+		if !ok {
+			return fmt.Errorf("key %q is not in the schema", key)
+		}
+
+		valueType := reflect.TypeOf(m[key])
+		if t.Kind() == reflect.Interface {
+			if valueType != nil && !valueType.Implements(t) {
+				return fmt.Errorf("value for key %q (%s) does not implement %s", key, valueType, t)
+			}
+		} else if valueType != t {
+			return fmt.Errorf("value for key %q (%v) is not of type %s", key, valueType, t)
+		}
+	}
+	return nil
 }
 
 // Type returns the reflect.Type of the BindingParam.
@@ -96,6 +291,170 @@ func (bp BindingParam) Type() reflect.Type {
 	return bp.t
 }
 
+// Name returns the name of the BindingParam.
+func (bp BindingParam) Name() string { return bp.name }
+
+// IsRequired returns whether the BindingParam is required.
+func (bp BindingParam) IsRequired() bool { return bp.required }
+
+// IsVariadic returns whether the BindingParam is variadic. The reflect.Type of a variadic BindingParam is a slice type.
+func (bp BindingParam) IsVariadic() bool { return bp.variadic }
+
+// paramGroupKind is the kind of constraint that a BindingParamGroup places on its BindingParam(s).
+type paramGroupKind int
+
+const (
+	exactlyOne paramGroupKind = iota
+	atMostOne
+)
+
+// BindingParamGroup constrains which of a group of BindingParam(s) can be provided together for a Binding. A
+// BindingParam is treated as provided when an argument is given for it that is not equal to its default value. To
+// create a BindingParamGroup use the available constructors:
+//   - ExactlyOne
+//   - AtMostOne
+//
+// BindingParamGroup(s) are set for a Binding using Binding.SetParamGroups.
+type BindingParamGroup struct {
+	kind  paramGroupKind
+	names []string
+}
+
+// ExactlyOne returns a BindingParamGroup where exactly one of the BindingParam(s) of the given names must be provided.
+// For example, a Binding that can fetch a resource by either its ID or its slug:
+//
+//	ExactlyOne("id", "slug")
+func ExactlyOne(names ...string) BindingParamGroup {
+	return BindingParamGroup{kind: exactlyOne, names: names}
+}
+
+// AtMostOne returns a BindingParamGroup where at most one of the BindingParam(s) of the given names can be provided.
+func AtMostOne(names ...string) BindingParamGroup {
+	return BindingParamGroup{kind: atMostOne, names: names}
+}
+
+// Names returns the names of the BindingParam(s) within the BindingParamGroup.
+func (g BindingParamGroup) Names() []string { return g.names }
+
+// String returns the string representation of the BindingParamGroup in the format:
+//
+//	<"ExactlyOne"|"AtMostOne">(<name>, ...)
+func (g BindingParamGroup) String() string {
+	kind := "ExactlyOne"
+	if g.kind == atMostOne {
+		kind = "AtMostOne"
+	}
+	return fmt.Sprintf("%s(%s)", kind, strings.Join(g.names, ", "))
+}
+
+// check checks whether the given arguments, before defaults are applied, satisfy the BindingParamGroup for the given
+// BindingParam(s).
+func (g BindingParamGroup) check(params []BindingParam, args []any) error {
+	provided := make([]string, 0, len(g.names))
+	for _, name := range g.names {
+		i := -1
+		for j, param := range params {
+			if param.name == name {
+				i = j
+				break
+			}
+		}
+
+		if i == -1 {
+			return fmt.Errorf("param %q within %s does not exist", name, g)
+		}
+
+		param := params[i]
+		switch {
+		case i >= len(args):
+			continue
+		case param.variadic, !reflect.DeepEqual(args[i], param.defaultValue):
+			provided = append(provided, name)
+		}
+	}
+
+	switch {
+	case g.kind == exactlyOne && len(provided) == 0:
+		return fmt.Errorf("exactly one of the params %s must be provided, but none were", strings.Join(g.names, ", "))
+	case len(provided) > 1:
+		return fmt.Errorf(
+			"%s one of the params %s can be provided, but %s were",
+			map[paramGroupKind]string{exactlyOne: "exactly", atMostOne: "at most"}[g.kind],
+			strings.Join(g.names, ", "), strings.Join(provided, ", "),
+		)
+	}
+	return nil
+}
+
+// parseArg parses the given string argument into the given type by unmarshalling it as JSON. If the type is a string
+// then the argument will be quoted before it is unmarshalled.
+func parseArg(t reflect.Type, arg string) (any, error) {
+	if t.Kind() == reflect.String {
+		arg = fmt.Sprintf("%q", arg)
+	}
+	val := reflect.New(t)
+	if err := json.Unmarshal([]byte(arg), val.Interface()); err != nil {
+		return nil, err
+	}
+	return val.Elem().Interface(), nil
+}
+
+// namedArgs converts the given map of BindingParam names to arguments into a list of positional arguments for the
+// given BindingParam(s). Non-required BindingParam(s) that are not given will be set to their default value, unless
+// they are trailing BindingParam(s), in which case they will be left to be defaulted by Binding.Execute. The argument
+// for a variadic BindingParam can be given as a slice of elements that will be spread into the positional arguments.
+func namedArgs(params []BindingParam, named map[string]any) (args []any, err error) {
+	paramNames := make(map[string]struct{})
+	for _, param := range params {
+		paramNames[param.name] = struct{}{}
+	}
+
+	for name := range named {
+		if _, ok := paramNames[name]; !ok {
+			err = paramErrorf(name, -1, "param %q does not exist", name)
+			return
+		}
+	}
+
+	args = make([]any, 0)
+	defaults := make([]any, 0)
+	for i, param := range params {
+		arg, ok := named[param.name]
+		switch {
+		case !ok && param.required:
+			err = paramErrorf(param.name, i, "required param %q (no. %d) was not provided as a named argument", param.name, i)
+			return
+		case !ok:
+			if !param.variadic {
+				var def any
+				if def, err = param.defaultArg(); err != nil {
+					err = paramErrorf(param.name, i, "%w", err)
+					return
+				}
+				defaults = append(defaults, def)
+			}
+			continue
+		}
+
+		// We only push the default values for the non-required params that were not given when a param after them
+		// is given.
+		args = append(args, defaults...)
+		defaults = defaults[:0]
+
+		if param.variadic {
+			val := reflect.ValueOf(arg)
+			if val.Kind() == reflect.Slice || val.Kind() == reflect.Array {
+				for j := 0; j < val.Len(); j++ {
+					args = append(args, val.Index(j).Interface())
+				}
+				continue
+			}
+		}
+		args = append(args, arg)
+	}
+	return
+}
+
 // Param returns a non-required BindingParam with the given name and default value. The required type for this
 // BindingParam will be found using reflection on this default value.
 func Param(name string, val any) BindingParam {
@@ -120,7 +479,10 @@ func ReqParam(name string, val any) BindingParam {
 	}
 }
 
-// VarParam returns a variadic BindingParam with the given name and type (reflected from the given value).
+// VarParam returns a variadic BindingParam with the given name and type (reflected from the given value). The given
+// value should be an empty slice of the element type, such as []int{}. The arguments for a variadic BindingParam can
+// either be given as separate elements, or as a single slice of the same type as the BindingParam, which will be
+// spread into separate elements.
 func VarParam(name string, val any) BindingParam {
 	t, interfaceFlag, defV := getReflectType(val)
 	return BindingParam{
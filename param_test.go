@@ -0,0 +1,249 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBindingParam_Secret(t *testing.T) {
+	params := []BindingParam{ReqParam("user", ""), ReqParam("token", "").Secret(), Param("page", 1)}
+	if !params[1].IsSecret() || params[0].IsSecret() {
+		t.Errorf("only the \"token\" param should be secret")
+	}
+
+	if expected := "token: string"; params[1].String() != expected {
+		t.Errorf("expected %q, not %q", expected, params[1].String())
+	}
+
+	if expected, actual := "apiKey: string? = ****", Param("apiKey", "default").Secret().String(); actual != expected {
+		t.Errorf("expected %q, not %q", expected, actual)
+	}
+
+	client := &mockRateLimitedClient{mockClient: &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		return []int{}, nil
+	}}}
+
+	binding := NewBindingChain(mockRequestMethod[[]int, []int]).SetParamsMethod(func(binding Binding[[]int, []int]) []BindingParam {
+		return params
+	}).SetPaginated(true).SetName("secret")
+
+	logger := &recordingLogger{}
+	api := NewAPI(client, Schema{"secret": WrapBinding(binding)}, WithLogger(logger))
+	if _, err := api.Execute("secret", "andy", "hunter2", 1); err != nil {
+		t.Fatalf("could not execute Binding: %v", err)
+	}
+
+	paginator, err := NewTypedPaginator(client, 0, binding, "andy", "hunter2")
+	if err != nil {
+		t.Fatalf("could not create Paginator: %v", err)
+	}
+
+	if _, err = paginator.All(); err != nil {
+		t.Fatalf("could not fetch all pages: %v", err)
+	}
+
+	logs := append(*logger, client.logs...)
+	if len(*logger) == 0 || len(client.logs) == 0 {
+		t.Fatalf("expected both the API and the Paginator to log, got %q and %q", *logger, client.logs)
+	}
+
+	for _, log := range logs {
+		if strings.Contains(log, "hunter2") {
+			t.Errorf("log %q contains the secret arg", log)
+		}
+
+		if !strings.Contains(log, "[andy **** 1]") {
+			t.Errorf("log %q does not contain the redacted args", log)
+		}
+	}
+}
+
+func TestBindingParam_MapSchema(t *testing.T) {
+	binding := NewBindingChain(mockRequestMethod[bool, bool]).SetParamsMethod(func(binding Binding[bool, bool]) []BindingParam {
+		return []BindingParam{
+			ReqParam("itemName", ""),
+			Param("columnValues", map[string]any{}).MapSchema(map[string]reflect.Type{
+				"status": reflect.TypeOf(""),
+				"date":   reflect.TypeOf(time.Time{}),
+				"person": reflect.TypeOf((*fmt.Stringer)(nil)).Elem(),
+			}),
+		}
+	}).(*bindingProto[bool, bool])
+
+	for testNo, test := range []struct {
+		columnValues map[string]any
+		expectedErr  string
+	}{
+		{columnValues: map[string]any{"status": "Done", "date": time.Now()}},
+		{columnValues: map[string]any{"person": time.Second}},
+		{columnValues: map[string]any{}},
+		{
+			columnValues: map[string]any{"status": 1},
+			expectedErr:  "param \"columnValues\"'s arg no. 1 does not match its map schema: value for key \"status\" (int) is not of type string",
+		},
+		{
+			columnValues: map[string]any{"status": "Done", "priority": "High"},
+			expectedErr:  "param \"columnValues\"'s arg no. 1 does not match its map schema: key \"priority\" is not in the schema",
+		},
+		{
+			columnValues: map[string]any{"person": "andy"},
+			expectedErr:  "param \"columnValues\"'s arg no. 1 does not match its map schema: value for key \"person\" (string) does not implement fmt.Stringer",
+		},
+	} {
+		_, err := binding.TypeCheckArgs("item", test.columnValues)
+		switch {
+		case test.expectedErr == "" && err != nil:
+			t.Errorf("test no. %d returned an unexpected error: %v", testNo+1, err)
+		case test.expectedErr != "" && (err == nil || err.Error() != test.expectedErr):
+			t.Errorf("test no. %d expected error %q, not %v", testNo+1, test.expectedErr, err)
+		}
+	}
+}
+
+func TestBindingParam_Doc(t *testing.T) {
+	for testNo, test := range []struct {
+		param          BindingParam
+		expectedString string
+		expectedJSON   string
+	}{
+		{
+			param:          Param("limit", 10).Doc("The maximum number of items to return"),
+			expectedString: "limit: int? = 10 // The maximum number of items to return",
+			expectedJSON:   `{"name":"limit","type":"int","required":false,"variadic":false,"default":10,"doc":"The maximum number of items to return"}`,
+		},
+		{
+			param:          ReqParam("id", 0),
+			expectedString: "id: int",
+			expectedJSON:   `{"name":"id","type":"int","required":true,"variadic":false}`,
+		},
+		{
+			param:          Param("token", "abc").Secret().Doc("API token"),
+			expectedString: "token: string? = **** // API token",
+			expectedJSON:   `{"name":"token","type":"string","required":false,"variadic":false,"default":"****","doc":"API token"}`,
+		},
+	} {
+		if actual := test.param.String(); actual != test.expectedString {
+			t.Errorf("test no. %d expected String %q, not %q", testNo+1, test.expectedString, actual)
+		}
+
+		if actual, err := json.Marshal(test.param); err != nil {
+			t.Errorf("test no. %d could not marshal param: %v", testNo+1, err)
+		} else if string(actual) != test.expectedJSON {
+			t.Errorf("test no. %d expected JSON %s, not %s", testNo+1, test.expectedJSON, actual)
+		}
+	}
+}
+
+func TestVarParam_SpreadSlice(t *testing.T) {
+	binding := NewBindingChain(mockRequestMethod[bool, bool]).SetParamsMethod(func(binding Binding[bool, bool]) []BindingParam {
+		return []BindingParam{ReqParam("name", ""), VarParam("ids", []int{})}
+	}).(*bindingProto[bool, bool])
+
+	anyBinding := NewBindingChain(mockRequestMethod[bool, bool]).SetParamsMethod(func(binding Binding[bool, bool]) []BindingParam {
+		return []BindingParam{VarParam("values", []any{})}
+	}).(*bindingProto[bool, bool])
+
+	for testNo, test := range []struct {
+		binding      *bindingProto[bool, bool]
+		args         []any
+		expectedArgs []any
+		expectedErr  bool
+	}{
+		{binding: binding, args: []any{"a", []int{1, 2, 3}}, expectedArgs: []any{"a", 1, 2, 3}},
+		{binding: binding, args: []any{"a", 1, 2, 3}, expectedArgs: []any{"a", 1, 2, 3}},
+		{binding: binding, args: []any{"a", []int{}}, expectedArgs: []any{"a"}},
+		// Slices can only be spread when they are the only variadic argument
+		{binding: binding, args: []any{"a", []int{1}, 2}, expectedErr: true},
+		// A []any is a valid element of a variadic []any param, so it is not spread
+		{binding: anyBinding, args: []any{[]any{1, 2}}, expectedArgs: []any{[]any{1, 2}}},
+	} {
+		actual, err := test.binding.TypeCheckArgs(test.args...)
+		switch {
+		case test.expectedErr != (err != nil):
+			t.Errorf("test no. %d expected error to be %t, got %v", testNo+1, test.expectedErr, err)
+		case !test.expectedErr && !reflect.DeepEqual(actual, test.expectedArgs):
+			t.Errorf("test no. %d expected args %v, not %v", testNo+1, test.expectedArgs, actual)
+		}
+	}
+}
+
+func TestBindingParamGroup(t *testing.T) {
+	params := func(binding Binding[bool, bool]) []BindingParam {
+		return []BindingParam{Param("id", 0), Param("slug", ""), Param("expand", false)}
+	}
+	exactlyOneBinding := NewBindingChain(mockRequestMethod[bool, bool]).
+		SetParamsMethod(params).
+		SetParamGroups(ExactlyOne("id", "slug")).(*bindingProto[bool, bool])
+	atMostOneBinding := NewBindingChain(mockRequestMethod[bool, bool]).
+		SetParamsMethod(params).
+		SetParamGroups(AtMostOne("id", "slug")).(*bindingProto[bool, bool])
+	missingBinding := NewBindingChain(mockRequestMethod[bool, bool]).
+		SetParamsMethod(params).
+		SetParamGroups(AtMostOne("id", "uuid")).(*bindingProto[bool, bool])
+
+	for testNo, test := range []struct {
+		binding     *bindingProto[bool, bool]
+		args        []any
+		expectedErr string
+	}{
+		{binding: exactlyOneBinding, args: []any{}, expectedErr: "exactly one of the params id, slug must be provided, but none were"},
+		{binding: exactlyOneBinding, args: []any{0, "", true}, expectedErr: "exactly one of the params id, slug must be provided, but none were"},
+		{binding: exactlyOneBinding, args: []any{1}},
+		{binding: exactlyOneBinding, args: []any{0, "gapi"}},
+		{binding: exactlyOneBinding, args: []any{1, "gapi"}, expectedErr: "exactly one of the params id, slug can be provided, but id, slug were"},
+		{binding: atMostOneBinding, args: []any{}},
+		{binding: atMostOneBinding, args: []any{0, "gapi"}},
+		{binding: atMostOneBinding, args: []any{1, "gapi"}, expectedErr: "at most one of the params id, slug can be provided, but id, slug were"},
+		{binding: missingBinding, args: []any{1}, expectedErr: `param "uuid" within AtMostOne(id, uuid) does not exist`},
+	} {
+		_, err := test.binding.TypeCheckArgs(test.args...)
+		switch {
+		case test.expectedErr == "" && err != nil:
+			t.Errorf("test no. %d expected no error, got %v", testNo+1, err)
+		case test.expectedErr != "" && (err == nil || err.Error() != test.expectedErr):
+			t.Errorf("test no. %d expected error %q, got %v", testNo+1, test.expectedErr, err)
+		}
+	}
+}
+
+func TestBindingParam_FromEnv(t *testing.T) {
+	binding := NewBindingChain(mockRequestMethod[bool, bool]).SetParamsMethod(func(binding Binding[bool, bool]) []BindingParam {
+		return []BindingParam{Param("region", "eu").FromEnv("GAPI_TEST_REGION"), Param("limit", 10).FromEnv("GAPI_TEST_LIMIT")}
+	}).(*bindingProto[bool, bool])
+
+	for testNo, test := range []struct {
+		env          map[string]string
+		args         []any
+		expectedArgs []any
+		expectedErr  bool
+	}{
+		{env: map[string]string{}, expectedArgs: []any{"eu", 10}},
+		{env: map[string]string{"GAPI_TEST_REGION": "us", "GAPI_TEST_LIMIT": "50"}, expectedArgs: []any{"us", 50}},
+		// Arguments that are provided are not overridden by the environment
+		{env: map[string]string{"GAPI_TEST_REGION": "us", "GAPI_TEST_LIMIT": "50"}, args: []any{"asia", 20}, expectedArgs: []any{"asia", 20}},
+		{env: map[string]string{"GAPI_TEST_LIMIT": "fifty"}, expectedErr: true},
+	} {
+		for _, key := range []string{"GAPI_TEST_REGION", "GAPI_TEST_LIMIT"} {
+			value, ok := test.env[key]
+			if !ok {
+				_ = os.Unsetenv(key)
+				continue
+			}
+			t.Setenv(key, value)
+		}
+
+		actual, err := binding.TypeCheckArgs(test.args...)
+		switch {
+		case test.expectedErr != (err != nil):
+			t.Errorf("test no. %d expected error to be %t, got %v", testNo+1, test.expectedErr, err)
+		case !test.expectedErr && !reflect.DeepEqual(actual, test.expectedArgs):
+			t.Errorf("test no. %d expected args %v, not %v", testNo+1, test.expectedArgs, actual)
+		}
+	}
+}
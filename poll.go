@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+// pollRedirect is the configuration set by Binding.SetPollRedirect.
+type pollRedirect struct {
+	interval time.Duration
+	maxWait  time.Duration
+}
+
+// pollLocation returns the Location header of the latest response for the Binding of the given name, if that response
+// has a 202 (Accepted) or 303 (See Other) status code. The given MetaRecorder is used to fetch the latest response.
+func pollLocation(recorder MetaRecorder, bindingName string) (string, bool) {
+	meta, ok := recorder.LatestMeta(bindingName)
+	if !ok || (meta.StatusCode != http.StatusAccepted && meta.StatusCode != http.StatusSeeOther) {
+		return "", false
+	}
+
+	location := meta.Header.Get("Location")
+	return location, location != ""
+}
+
+// poll follows the Location header of 202/303 responses by repeatedly executing GET requests to that location, until a
+// response with a different status code is received. The Request and response wrapper of the last execution are
+// returned. If the latest response does not need to be polled, then the given Request and response wrapper are
+// returned as is.
+func (b bindingProto[ResT, RetT]) poll(
+	ctx context.Context,
+	client Client,
+	attrs map[string]any,
+	req Request,
+	responseWrapper reflect.Value,
+	responseWrapperInt any,
+	args ...any,
+) (Request, reflect.Value, any, error) {
+	recorder, ok := client.(MetaRecorder)
+	if !ok {
+		return req, responseWrapper, responseWrapperInt, fmt.Errorf(
+			"cannot poll redirects for Binding %T as Client %T is not a MetaRecorder", b, client,
+		)
+	}
+
+	start := time.Now()
+	for {
+		location, ok := pollLocation(recorder, b.Name())
+		if !ok {
+			return req, responseWrapper, responseWrapperInt, nil
+		}
+
+		if b.pollRedirect.maxWait > 0 && time.Since(start)+b.pollRedirect.interval > b.pollRedirect.maxWait {
+			return req, responseWrapper, responseWrapperInt, fmt.Errorf(
+				"gave up polling %q for Binding %T after %s", location, b, b.pollRedirect.maxWait,
+			)
+		}
+
+		httpReq, ok := req.(HTTPRequest)
+		if !ok || httpReq.Request == nil {
+			return req, responseWrapper, responseWrapperInt, fmt.Errorf(
+				"cannot poll %q for Binding %T as its Request is not a HTTPRequest", location, b,
+			)
+		}
+
+		u, err := httpReq.URL.Parse(location)
+		if err != nil {
+			return req, responseWrapper, responseWrapperInt, errors.Wrapf(err, "could not parse Location %q", location)
+		}
+
+		timer := time.NewTimer(b.pollRedirect.interval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return req, responseWrapper, responseWrapperInt, errors.Wrapf(
+				ctx.Err(), "context is done whilst polling %q for Binding %T", location, b,
+			)
+		}
+
+		// The headers of the original Request are kept so that polling is authenticated in the same way
+		var pollReq *http.Request
+		if pollReq, err = http.NewRequestWithContext(httpReq.Context(), http.MethodGet, u.String(), nil); err != nil {
+			return req, responseWrapper, responseWrapperInt, err
+		}
+		pollReq.Header = httpReq.Request.Header.Clone()
+		req = HTTPRequest{pollReq}
+
+		if responseWrapper, err = b.ResponseWrapper(args...); err != nil {
+			return req, responseWrapper, responseWrapperInt, errors.Wrapf(
+				err, "could not execute ResponseWrapper for Binding %T", b,
+			)
+		}
+		responseWrapperInt = responseWrapper.Interface()
+
+		if err = client.Run(withExecution(ctx, b.Name(), 1), b.Name(), attrs, req, &responseWrapperInt); err != nil {
+			return req, responseWrapper, responseWrapperInt, errors.Wrapf(
+				err, "could not Execute Binding %T (%s)", b, describeRequest(req),
+			)
+		}
+	}
+}
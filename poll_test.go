@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBindingProto_SetPollRedirect(t *testing.T) {
+	type job struct {
+		Status string `json:"status"`
+	}
+
+	var polls atomic.Int64
+	mux := http.NewServeMux()
+	mux.HandleFunc("/jobs", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "/jobs/1")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("/jobs/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		// The job finishes on the second poll
+		if polls.Add(1) < 2 {
+			w.Header().Set("Location", "/jobs/1")
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status": "done"}`))
+	})
+	mux.HandleFunc("/never", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "/never")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	binding := NewRESTBinding[job, job](http.MethodPost, server.URL+"/jobs", nil, false, BearerAuth("token")).
+		SetPollRedirect(10*time.Millisecond, time.Second)
+
+	actual, err := binding.Execute(NewHTTPClient())
+	if err != nil {
+		t.Fatalf("could not execute Binding: %v", err)
+	}
+
+	if actual.Status != "done" {
+		t.Errorf("expected job status to be \"done\", not %q", actual.Status)
+	}
+
+	if polls.Load() != 2 {
+		t.Errorf("expected 2 polls, not %d", polls.Load())
+	}
+
+	// Polling should give up after the max wait
+	binding = NewRESTBinding[job, job](http.MethodPost, server.URL+"/never", nil, false).
+		SetPollRedirect(10*time.Millisecond, 50*time.Millisecond)
+	if _, err = binding.Execute(NewHTTPClient()); err == nil || !strings.Contains(err.Error(), "gave up polling") {
+		t.Errorf("expected an error after giving up polling, got %v", err)
+	}
+
+	// The Client must be a MetaRecorder
+	if _, err = binding.Execute(&mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		return job{}, nil
+	}}); err == nil || !strings.Contains(err.Error(), "is not a MetaRecorder") {
+		t.Errorf("expected an error for a Client that is not a MetaRecorder, got %v", err)
+	}
+}
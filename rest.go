@@ -0,0 +1,232 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// isEmptyArg returns true if the given argument is nil, or is an empty string/slice/array/map. Empty arguments are
+// not added to the query params of a Request constructed by a Binding created using NewRESTBinding.
+func isEmptyArg(arg any) bool {
+	if arg == nil {
+		return true
+	}
+
+	val := reflect.ValueOf(arg)
+	switch val.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return val.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return val.IsNil()
+	default:
+		return false
+	}
+}
+
+// QueryArrayStyle is the style in which slice arguments, and the arguments for variadic BindingParam(s), are
+// serialised into the query params of a Request constructed by a Binding created using NewRESTBinding. It can be set
+// using the QueryArrayStyleAttr Attr.
+type QueryArrayStyle int
+
+const (
+	// QueryArrayRepeat repeats the query param for each element: "ids=1&ids=2". This is the default QueryArrayStyle.
+	QueryArrayRepeat QueryArrayStyle = iota
+	// QueryArrayComma joins all elements with commas into a single query param: "ids=1,2".
+	QueryArrayComma
+	// QueryArrayBracket repeats the query param for each element with "[]" appended to its name: "ids[]=1&ids[]=2".
+	QueryArrayBracket
+)
+
+// String returns the name of the QueryArrayStyle.
+func (style QueryArrayStyle) String() string {
+	switch style {
+	case QueryArrayRepeat:
+		return "Repeat"
+	case QueryArrayComma:
+		return "Comma"
+	case QueryArrayBracket:
+		return "Bracket"
+	default:
+		return fmt.Sprintf("QueryArrayStyle(%d)", int(style))
+	}
+}
+
+// QueryArrayStyleAttrKey is the key of the Attr that sets the QueryArrayStyle of a Binding created using
+// NewRESTBinding.
+const QueryArrayStyleAttrKey = "queryArrayStyle"
+
+// QueryArrayStyleAttr returns an Attr that sets the QueryArrayStyle that is used to serialise slice arguments into the
+// query params of a Binding created using NewRESTBinding. For example:
+//
+//	NewRESTBinding[[]Board, []Board](http.MethodGet, "/boards", params, false, QueryArrayStyleAttr(QueryArrayComma))
+func QueryArrayStyleAttr(style QueryArrayStyle) Attr {
+	return func(client Client) (string, any) { return QueryArrayStyleAttrKey, style }
+}
+
+// addQueryArray adds the given elements of a slice argument to the query params under the given name using the given
+// QueryArrayStyle.
+func addQueryArray(query url.Values, name string, elems []string, style QueryArrayStyle) {
+	switch style {
+	case QueryArrayComma:
+		query.Set(name, strings.Join(elems, ","))
+	case QueryArrayBracket:
+		query[name+"[]"] = elems
+	default:
+		query[name] = elems
+	}
+}
+
+// restURL constructs the URL for a Binding created with NewRESTBinding. Each "{name}" placeholder in the given URL
+// template is replaced by the argument for the BindingParam of the same name, and, if addQuery is set, all remaining
+// non-empty arguments are added as query params. Slice arguments, and the arguments for variadic BindingParam(s), are
+// added using the QueryArrayStyle set by QueryArrayStyleAttr. If the URL template is relative, then it will be joined
+// onto the base URL set using the WithBaseURL APIOption (if there is one).
+func restURL(urlTemplate string, params []BindingParam, args []any, attrs map[string]any, addQuery bool) (u *url.URL, err error) {
+	query := make(url.Values)
+	arrays := make(map[string][]string)
+	arrayNames := make([]string, 0)
+	addArrayElem := func(name string, elem any) {
+		if _, ok := arrays[name]; !ok {
+			arrayNames = append(arrayNames, name)
+		}
+		arrays[name] = append(arrays[name], fmt.Sprint(elem))
+	}
+
+	for i, arg := range args {
+		var param BindingParam
+		switch {
+		case i < len(params):
+			param = params[i]
+		case len(params) > 0 && params[len(params)-1].variadic:
+			param = params[len(params)-1]
+		default:
+			continue
+		}
+
+		placeholder := "{" + param.name + "}"
+		if strings.Contains(urlTemplate, placeholder) {
+			urlTemplate = strings.ReplaceAll(urlTemplate, placeholder, url.PathEscape(fmt.Sprint(arg)))
+			continue
+		}
+
+		if !addQuery || isEmptyArg(arg) {
+			continue
+		}
+
+		val := reflect.ValueOf(arg)
+		switch {
+		case val.Kind() == reflect.Slice || val.Kind() == reflect.Array:
+			for j := 0; j < val.Len(); j++ {
+				addArrayElem(param.name, val.Index(j).Interface())
+			}
+		case param.variadic:
+			addArrayElem(param.name, arg)
+		default:
+			query.Add(param.name, fmt.Sprint(arg))
+		}
+	}
+
+	style, _ := attrs[QueryArrayStyleAttrKey].(QueryArrayStyle)
+	for _, name := range arrayNames {
+		addQueryArray(query, name, arrays[name], style)
+	}
+
+	// Fields given as an argument take precedence over the fields set by Binding.SetFields
+	if fields := fieldsFromAttrs(attrs); addQuery && len(fields) > 0 && !query.Has(FieldsQueryParam) {
+		query.Set(FieldsQueryParam, strings.Join(fields, ","))
+	}
+
+	if baseURL, ok := attrs[BaseURLAttrKey].(string); ok && strings.HasPrefix(urlTemplate, "/") {
+		urlTemplate = strings.TrimSuffix(baseURL, "/") + urlTemplate
+	}
+
+	if u, err = url.Parse(urlTemplate); err != nil {
+		return
+	}
+
+	if len(query) > 0 {
+		existing := u.Query()
+		for key, values := range query {
+			existing[key] = append(existing[key], values...)
+		}
+		u.RawQuery = existing.Encode()
+	}
+	return
+}
+
+// NewRESTBinding creates a new Binding for a REST API endpoint. The Request for the Binding is a HTTPRequest that uses
+// the given HTTP method and URL template. The URL template can contain placeholders of the form "{name}", which will be
+// replaced by the argument for the BindingParam of the same name. All remaining arguments that are not empty will be
+// added to the URL as query params, using the name of their BindingParam as the key. Variadic arguments, and arguments
+// that are slices, are added as repeated query params by default. The QueryArrayStyleAttr Attr can be passed to change
+// this to another QueryArrayStyle. Fields declared using Binding.SetFields are added to the FieldsQueryParam.
+//
+// The URL template can also be a path relative to the base URL of an API (see WithBaseURL), such as "/users/{id}".
+//
+// The method and URL template are also set as the request template of the Binding, so they can be fetched using
+// Binding.RequestTemplate. The params, paginated, and attrs parameters are the same as those of NewBinding.
+func NewRESTBinding[ResT any, RetT any](
+	method string,
+	urlTemplate string,
+	params BindingParamsMethod[ResT, RetT],
+	paginated bool,
+	attrs ...Attr,
+) Binding[ResT, RetT] {
+	return NewBinding[ResT, RetT](nil, nil, nil, nil, params, paginated, attrs...).SetRequestMethodE(func(binding Binding[ResT, RetT], args ...any) (request Request, err error) {
+		var u *url.URL
+		if u, err = restURL(urlTemplate, binding.Params(), args, binding.Attrs(), true); err != nil {
+			err = errors.Wrapf(err, "could not construct URL from template %q", urlTemplate)
+			return
+		}
+
+		var req *http.Request
+		if req, err = http.NewRequest(method, u.String(), nil); err != nil {
+			return
+		}
+		return HTTPRequest{req}, nil
+	}).SetRequestTemplate(method, urlTemplate)
+}
+
+// NewJSONBodyBinding creates a new Binding for a REST API endpoint that takes a JSON body, such as a POST or PUT
+// endpoint that creates/updates a resource. The Request for the Binding is a HTTPRequest that uses the given HTTP
+// method and URL template. The URL template is handled in the same way as in NewRESTBinding, except that arguments
+// that are not used within the URL template are not added as query params. Instead, the bodyFromArgs function is
+// called with the arguments passed to Binding.Execute, and the returned value is marshalled to JSON and set as the body
+// of the HTTPRequest. The "Content-Type" header of the HTTPRequest is also set to "application/json". If the value
+// cannot be marshalled to JSON, then the error will be returned by Binding.Execute.
+//
+// The params and attrs parameters are the same as those of NewBinding.
+func NewJSONBodyBinding[ResT any, RetT any](
+	method string,
+	urlTemplate string,
+	bodyFromArgs func(args ...any) any,
+	params BindingParamsMethod[ResT, RetT],
+	attrs ...Attr,
+) Binding[ResT, RetT] {
+	return NewBinding[ResT, RetT](nil, nil, nil, nil, params, false, attrs...).SetRequestMethodE(func(binding Binding[ResT, RetT], args ...any) (request Request, err error) {
+		var u *url.URL
+		if u, err = restURL(urlTemplate, binding.Params(), args, binding.Attrs(), false); err != nil {
+			err = errors.Wrapf(err, "could not construct URL from template %q", urlTemplate)
+			return
+		}
+
+		var body []byte
+		if body, err = json.Marshal(bodyFromArgs(args...)); err != nil {
+			err = errors.Wrap(err, "could not marshal body to JSON")
+			return
+		}
+
+		var req *http.Request
+		if req, err = http.NewRequest(method, u.String(), bytes.NewReader(body)); err != nil {
+			return
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return HTTPRequest{req}, nil
+	}).SetRequestTemplate(method, urlTemplate)
+}
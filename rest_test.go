@@ -0,0 +1,163 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBinding_RequestTemplate(t *testing.T) {
+	for testNo, test := range []struct {
+		binding        BindingWrapper
+		expectedMethod string
+		expectedURL    string
+		expectedOk     bool
+	}{
+		{
+			binding:        WrapBinding(NewBindingChain(mockRequestMethod[bool, bool]).SetRequestTemplate(http.MethodPut, "/items/{id}")),
+			expectedMethod: http.MethodPut,
+			expectedURL:    "/items/{id}",
+			expectedOk:     true,
+		},
+		{
+			binding: WrapBinding(NewRESTBinding[bool, bool](http.MethodGet, "https://example.com/users/{id}", func(binding Binding[bool, bool]) []BindingParam {
+				return Params("id", 0, true)
+			}, false)),
+			expectedMethod: http.MethodGet,
+			expectedURL:    "https://example.com/users/{id}",
+			expectedOk:     true,
+		},
+		{
+			binding: WrapBinding(NewBindingChain(mockRequestMethod[bool, bool])),
+		},
+	} {
+		method, urlTemplate, ok := test.binding.RequestTemplate()
+		if method != test.expectedMethod || urlTemplate != test.expectedURL || ok != test.expectedOk {
+			t.Errorf(
+				"test no. %d expected request template (%q, %q, %t), not (%q, %q, %t)",
+				testNo+1, test.expectedMethod, test.expectedURL, test.expectedOk, method, urlTemplate, ok,
+			)
+		}
+	}
+}
+
+func TestNewRESTBinding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(r.Method + " " + r.URL.RequestURI())
+	}))
+	defer server.Close()
+
+	binding := NewRESTBinding[string, string](http.MethodGet, "/users/{id}/posts", func(binding Binding[string, string]) []BindingParam {
+		return Params("id", 0, true, "search", "", "tags", []string{}, false, true)
+	}, false)
+
+	api := NewAPI(httpClient{}, Schema{"posts": WrapBinding(binding)}, WithBaseURL(server.URL))
+	for testNo, test := range []struct {
+		args     []any
+		expected string
+	}{
+		{[]any{1}, "GET /users/1/posts"},
+		{[]any{2, "hello world"}, "GET /users/2/posts?search=hello+world"},
+		{[]any{3, "", "a", "b"}, "GET /users/3/posts?tags=a&tags=b"},
+	} {
+		actual, err := api.Execute("posts", test.args...)
+		if err != nil {
+			t.Errorf("test no. %d could not execute Binding: %v", testNo+1, err)
+			continue
+		}
+
+		if actual.(string) != test.expected {
+			t.Errorf("test no. %d expected %q, not %q", testNo+1, test.expected, actual)
+		}
+	}
+}
+
+func TestQueryArrayStyleAttr(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(r.URL.RawQuery)
+	}))
+	defer server.Close()
+
+	params := func(binding Binding[string, string]) []BindingParam {
+		return Params("boardIds", []int{}, "status", "", "tags", []string{}, false, true)
+	}
+
+	for testNo, test := range []struct {
+		attrs    []Attr
+		args     []any
+		expected string
+	}{
+		{nil, []any{[]int{1, 2}, "open", "a", "b"}, "boardIds=1&boardIds=2&status=open&tags=a&tags=b"},
+		{[]Attr{QueryArrayStyleAttr(QueryArrayRepeat)}, []any{[]int{1, 2}, "open", "a", "b"}, "boardIds=1&boardIds=2&status=open&tags=a&tags=b"},
+		{[]Attr{QueryArrayStyleAttr(QueryArrayComma)}, []any{[]int{1, 2}, "open", "a", "b"}, "boardIds=1%2C2&status=open&tags=a%2Cb"},
+		{[]Attr{QueryArrayStyleAttr(QueryArrayBracket)}, []any{[]int{1, 2}, "open", "a", "b"}, "boardIds%5B%5D=1&boardIds%5B%5D=2&status=open&tags%5B%5D=a&tags%5B%5D=b"},
+		{[]Attr{QueryArrayStyleAttr(QueryArrayComma)}, []any{[]int{1}}, "boardIds=1"},
+	} {
+		binding := NewRESTBinding[string, string](http.MethodGet, server.URL+"/boards", params, false, test.attrs...)
+		actual, err := binding.Execute(httpClient{}, test.args...)
+		if err != nil {
+			t.Errorf("test no. %d could not execute Binding: %v", testNo+1, err)
+		} else if actual != test.expected {
+			t.Errorf("test no. %d expected query %q, not %q", testNo+1, test.expected, actual)
+		}
+	}
+}
+
+func TestNewJSONBodyBinding(t *testing.T) {
+	type item struct {
+		Name string   `json:"name"`
+		Tags []string `json:"tags"`
+	}
+
+	type echo struct {
+		Method      string          `json:"method"`
+		Path        string          `json:"path"`
+		ContentType string          `json:"contentType"`
+		Body        json.RawMessage `json:"body"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body json.RawMessage
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_ = json.NewEncoder(w).Encode(echo{
+			Method:      r.Method,
+			Path:        r.URL.RequestURI(),
+			ContentType: r.Header.Get("Content-Type"),
+			Body:        body,
+		})
+	}))
+	defer server.Close()
+
+	binding := NewJSONBodyBinding[echo, echo](
+		http.MethodPost, server.URL+"/boards/{boardId}/items",
+		func(args ...any) any {
+			return item{Name: args[1].(string), Tags: args[2].([]string)}
+		},
+		func(binding Binding[echo, echo]) []BindingParam {
+			return Params("boardId", 0, true, "name", "", true, "tags", []string{})
+		},
+	)
+
+	actual, err := binding.Execute(httpClient{}, 1, "gapi", []string{"go"})
+	if err != nil {
+		t.Fatalf("could not execute Binding: %v", err)
+	}
+
+	if actual.Method != http.MethodPost || actual.Path != "/boards/1/items" || actual.ContentType != "application/json" {
+		t.Errorf("unexpected request %s %s (Content-Type: %q)", actual.Method, actual.Path, actual.ContentType)
+	}
+
+	if expected := `{"name":"gapi","tags":["go"]}`; string(actual.Body) != expected {
+		t.Errorf("expected body %s, not %s", expected, actual.Body)
+	}
+
+	// Values that cannot be marshalled should surface as an error from Execute
+	binding = NewJSONBodyBinding[echo, echo](http.MethodPost, server.URL, func(args ...any) any {
+		return func() {}
+	}, nil)
+
+	if _, err = binding.Execute(httpClient{}); err == nil {
+		t.Errorf("expected an error when marshalling the body, got nil")
+	}
+}
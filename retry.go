@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// RetryAfterError is an error that indicates how long to wait before retrying. For example, an error for an HTTP 429
+// response with a Retry-After header. When a RetryPolicy retries an error that wraps a RetryAfterError, the duration
+// returned by RetryAfter is preferred over RetryPolicy.Backoff.
+type RetryAfterError interface {
+	error
+	RetryAfter() time.Duration
+}
+
+// RetryPolicy configures how a Binding retries Client.Run when it returns an error. A RetryPolicy can be set for a
+// Binding using Binding.SetRetryPolicy.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times that Client.Run will be attempted. Values less than 2 disable retries.
+	MaxAttempts int
+	// Backoff returns how long to wait after the given attempt (starting at 1) fails. If Backoff is nil, then retries
+	// will not wait.
+	Backoff func(attempt int) time.Duration
+	// Retryable returns whether the given error returned by Client.Run should be retried. If Retryable is nil, then
+	// all errors will be retried.
+	Retryable func(err error) bool
+	// RetryNonIdempotent allows Binding(s) with a non-idempotent Method (such as POST or PATCH) to be retried. By
+	// default, these are never retried as retrying them could apply the same change twice.
+	RetryNonIdempotent bool
+}
+
+// ExponentialBackoff returns a RetryPolicy.Backoff function that waits for base, then doubles the wait after each
+// subsequent attempt. The wait is capped at max, unless max is 0 or less.
+func ExponentialBackoff(base time.Duration, max time.Duration) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		wait := base
+		for i := 1; i < attempt; i++ {
+			if wait *= 2; max > 0 && wait >= max {
+				break
+			}
+		}
+
+		if max > 0 && wait > max {
+			wait = max
+		}
+		return wait
+	}
+}
+
+// retryable returns whether the given error from the given attempt of a Binding with the given HTTP method should be
+// retried. A nil RetryPolicy never retries.
+func (rp *RetryPolicy) retryable(attempt int, method string, err error) bool {
+	if rp == nil || attempt >= rp.MaxAttempts || (!rp.RetryNonIdempotent && !isIdempotentMethod(method)) {
+		return false
+	}
+	return rp.Retryable == nil || rp.Retryable(err)
+}
+
+// wait waits before retrying the given attempt that failed with the given error. If the error is a RetryAfterError
+// then its RetryAfter duration is used instead of RetryPolicy.Backoff. An error is returned if the context.Context is
+// done before the wait is over.
+func (rp *RetryPolicy) wait(ctx context.Context, attempt int, err error) error {
+	var wait time.Duration
+	var retryAfterErr RetryAfterError
+	if errors.As(err, &retryAfterErr) && retryAfterErr.RetryAfter() > 0 {
+		wait = retryAfterErr.RetryAfter()
+	} else if rp.Backoff != nil {
+		wait = rp.Backoff(attempt)
+	}
+
+	if wait <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
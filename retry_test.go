@@ -0,0 +1,110 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// retryAfterError is a RetryAfterError that is returned by mock Client(s).
+type retryAfterError struct {
+	after time.Duration
+}
+
+func (e retryAfterError) Error() string {
+	return fmt.Sprintf("429 Too Many Requests (retry after %s)", e.after)
+}
+
+func (e retryAfterError) RetryAfter() time.Duration { return e.after }
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(100*time.Millisecond, time.Second)
+	actual := make([]time.Duration, 0)
+	for attempt := 1; attempt <= 6; attempt++ {
+		actual = append(actual, backoff(attempt))
+	}
+
+	expected := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected backoffs %v, not %v", expected, actual)
+	}
+}
+
+func TestBindingProto_SetRetryPolicy(t *testing.T) {
+	const retryAfter = 50 * time.Millisecond
+	client := &mockClient{}
+	client.run = func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		if client.requests == 1 {
+			return nil, retryAfterError{retryAfter}
+		}
+		return "ok", nil
+	}
+
+	// The Backoff is far longer than the context's timeout, so the test will only pass if Retry-After is preferred
+	binding := NewBindingChain(mockRequestMethod[string, string]).SetRetryPolicy(&RetryPolicy{
+		MaxAttempts: 3,
+		Backoff:     func(attempt int) time.Duration { return time.Minute },
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	response, err := binding.ExecuteCtx(ctx, client)
+	if err != nil {
+		t.Fatalf("could not execute Binding: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < retryAfter {
+		t.Errorf("expected retry to wait at least %s, waited %s", retryAfter, elapsed)
+	}
+
+	if response != "ok" {
+		t.Errorf("expected response %q, not %q", "ok", response)
+	}
+
+	if client.requests != 2 {
+		t.Errorf("expected 2 requests, not %d", client.requests)
+	}
+
+	// Errors that are not Retryable are returned after the first attempt
+	client.requests = 0
+	if _, err = binding.SetRetryPolicy(&RetryPolicy{
+		MaxAttempts: 3,
+		Retryable:   func(err error) bool { return false },
+	}).Execute(client); err == nil {
+		t.Errorf("expected an error when the error is not Retryable")
+	} else if client.requests != 1 {
+		t.Errorf("expected 1 request when the error is not Retryable, not %d", client.requests)
+	}
+
+	// Non-idempotent methods are only retried when RetryNonIdempotent is set
+	for testNo, test := range []struct {
+		method             string
+		retryNonIdempotent bool
+		expectedRequests   int
+	}{
+		{http.MethodPut, false, 2},
+		{http.MethodPost, false, 1},
+		{http.MethodPost, true, 2},
+	} {
+		client.requests = 0
+		_, err = binding.SetMethod(test.method).SetRetryPolicy(&RetryPolicy{
+			MaxAttempts:        3,
+			RetryNonIdempotent: test.retryNonIdempotent,
+		}).Execute(client)
+		if client.requests != test.expectedRequests {
+			t.Errorf("test no. %d expected %d request(s) for %s, not %d (%v)", testNo+1, test.expectedRequests, test.method, client.requests, err)
+		}
+	}
+}
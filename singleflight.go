@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// singleFlightCall is an in-flight or completed call within a singleFlightGroup.
+type singleFlightCall struct {
+	done chan struct{}
+	val  any
+	err  error
+	// cancelled is set when the call failed whilst the context.Context of its caller was done. The error is specific to
+	// that caller, so it is not shared with the callers that were waiting for the call.
+	cancelled bool
+}
+
+// singleFlightGroup deduplicates concurrent calls that share the same key, so that only one call is in-flight for each
+// key at a time. This is similar to golang.org/x/sync/singleflight.Group, except that waiting callers can give up when
+// their own context.Context is done.
+type singleFlightGroup struct {
+	mutex sync.Mutex
+	calls map[string]*singleFlightCall
+}
+
+// do calls the given function with the given context.Context for the given key, unless there is already an in-flight
+// call for that key, in which case it waits for the in-flight call to finish and returns its results. The second return
+// value is true if the results were shared with another caller.
+//
+// A waiting caller stops waiting and returns the error of its context.Context once it is done. If the in-flight call
+// fails because the context.Context of its caller is done, then the waiting callers will not share its error, and will
+// instead make the call again. If the given function panics, then the panic is recorded as an error for the waiting
+// callers, and the panic is propagated to the caller that made the call.
+func (g *singleFlightGroup) do(ctx context.Context, key string, fn func(ctx context.Context) (any, error)) (val any, err error, shared bool) {
+	for {
+		g.mutex.Lock()
+		if g.calls == nil {
+			g.calls = make(map[string]*singleFlightCall)
+		}
+
+		call, ok := g.calls[key]
+		if !ok {
+			call = &singleFlightCall{done: make(chan struct{})}
+			g.calls[key] = call
+			g.mutex.Unlock()
+			return g.call(ctx, key, call, fn)
+		}
+		g.mutex.Unlock()
+
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, ctx.Err(), false
+		}
+
+		if !call.cancelled {
+			return call.val, call.err, true
+		}
+	}
+}
+
+// call makes the given singleFlightCall for the given key by calling the given function, then removes it from the
+// singleFlightGroup.
+func (g *singleFlightGroup) call(ctx context.Context, key string, call *singleFlightCall, fn func(ctx context.Context) (any, error)) (val any, err error, shared bool) {
+	returned := false
+	defer func() {
+		var recovered any
+		if !returned {
+			recovered = recover()
+			call.val, call.err = nil, fmt.Errorf("single-flight call for %q did not return: %v", key, recovered)
+		}
+
+		g.mutex.Lock()
+		delete(g.calls, key)
+		g.mutex.Unlock()
+		close(call.done)
+
+		if recovered != nil {
+			panic(recovered)
+		}
+	}()
+
+	call.val, call.err = fn(ctx)
+	call.cancelled = call.err != nil && ctx.Err() != nil
+	returned = true
+	return call.val, call.err, false
+}
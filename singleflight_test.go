@@ -0,0 +1,182 @@
+package api
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// slowCountingClient is a Client that counts its runs and takes a while to respond, so that concurrent executions
+// overlap.
+type slowCountingClient struct {
+	runs atomic.Int64
+}
+
+func (c *slowCountingClient) Run(ctx context.Context, bindingName string, attrs map[string]any, req Request, res any) error {
+	c.runs.Add(1)
+	time.Sleep(50 * time.Millisecond)
+	*(*res.(*any)).(*int) = req.(*mockRequest).args[0].(int) * 2
+	return nil
+}
+
+func TestBindingProto_SetSingleFlight(t *testing.T) {
+	binding := NewBindingChain(mockRequestMethod[int, int]).SetParamsMethod(func(binding Binding[int, int]) []BindingParam {
+		return Params("n", 0, true)
+	}).SetName("double")
+
+	for testNo, test := range []struct {
+		singleFlight bool
+		method       string
+		forceCache   bool
+		expectedRuns int64
+	}{
+		{true, "", false, 1},
+		{false, "", false, 50},
+		{true, http.MethodGet, false, 1},
+		// Responses for unsafe methods are only shared when forced
+		{true, http.MethodPost, false, 50},
+		{true, http.MethodPost, true, 1},
+	} {
+		client := &slowCountingClient{}
+		b := binding.SetSingleFlight(test.singleFlight).SetMethod(test.method).SetForceCache(test.forceCache)
+
+		var wg sync.WaitGroup
+		start := make(chan struct{})
+		results := make([]int, 50)
+		errs := make([]error, 50)
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				<-start
+				results[i], errs[i] = b.Execute(client, 21)
+			}(i)
+		}
+		close(start)
+		wg.Wait()
+
+		for i := range results {
+			if errs[i] != nil || results[i] != 42 {
+				t.Errorf("test no. %d execution no. %d expected (42, nil), not (%d, %v)", testNo+1, i+1, results[i], errs[i])
+			}
+		}
+
+		if runs := client.runs.Load(); runs != test.expectedRuns {
+			t.Errorf("test no. %d expected the Client to run %d time(s), not %d", testNo+1, test.expectedRuns, runs)
+		}
+	}
+
+	// Executions with different arguments should not be deduplicated
+	client := &slowCountingClient{}
+	b := binding.SetSingleFlight(true)
+	futures := []*Future[int]{b.ExecuteAsync(client, 1), b.ExecuteAsync(client, 2)}
+	for i, future := range futures {
+		if actual, err := future.Wait(); err != nil || actual != (i+1)*2 {
+			t.Errorf("expected (%d, nil), not (%d, %v)", (i+1)*2, actual, err)
+		}
+	}
+
+	if runs := client.runs.Load(); runs != 2 {
+		t.Errorf("expected the Client to run 2 times for different arguments, not %d", runs)
+	}
+}
+
+func TestSingleFlightGroup_Do(t *testing.T) {
+	t.Run("FollowerContextDone", func(t *testing.T) {
+		var group singleFlightGroup
+		started, release := make(chan struct{}), make(chan struct{})
+		defer close(release)
+		go group.do(context.Background(), "key", func(ctx context.Context) (any, error) {
+			close(started)
+			<-release
+			return 42, nil
+		})
+		<-started
+
+		// The follower should stop waiting once its own context is done, even though the leader is still in-flight
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if _, err, shared := group.do(ctx, "key", func(ctx context.Context) (any, error) {
+			t.Errorf("follower should not make the call whilst the leader is in-flight")
+			return nil, nil
+		}); !errors.Is(err, context.DeadlineExceeded) || shared {
+			t.Errorf("expected the follower to return its own context error without sharing, not (%v, %t)", err, shared)
+		}
+	})
+
+	t.Run("LeaderContextDone", func(t *testing.T) {
+		var group singleFlightGroup
+		ctx, cancel := context.WithCancel(context.Background())
+		started := make(chan struct{})
+		leaderErr := make(chan error, 1)
+		go func() {
+			_, err, _ := group.do(ctx, "key", func(ctx context.Context) (any, error) {
+				close(started)
+				<-ctx.Done()
+				return nil, ctx.Err()
+			})
+			leaderErr <- err
+		}()
+		<-started
+
+		// The follower joins the leader's call, which fails once the leader's context is cancelled
+		followerVal := make(chan any, 1)
+		go func() {
+			val, err, _ := group.do(context.Background(), "key", func(ctx context.Context) (any, error) {
+				return 42, nil
+			})
+			if err != nil {
+				t.Errorf("expected the follower to not share the leader's context error, got %v", err)
+			}
+			followerVal <- val
+		}()
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+
+		if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+			t.Errorf("expected the leader to return context.Canceled, not %v", err)
+		}
+
+		if val := <-followerVal; val != 42 {
+			t.Errorf("expected the follower to make the call again and return 42, not %v", val)
+		}
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		var group singleFlightGroup
+		started, release := make(chan struct{}), make(chan struct{})
+		leaderPanic := make(chan any, 1)
+		go func() {
+			defer func() { leaderPanic <- recover() }()
+			group.do(context.Background(), "key", func(ctx context.Context) (any, error) {
+				close(started)
+				<-release
+				panic("boom")
+			})
+		}()
+		<-started
+
+		followerErr := make(chan error, 1)
+		go func() {
+			_, err, _ := group.do(context.Background(), "key", func(ctx context.Context) (any, error) {
+				return 42, nil
+			})
+			followerErr <- err
+		}()
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+
+		if recovered := <-leaderPanic; recovered != "boom" {
+			t.Errorf("expected the panic to be propagated to the leader, not %v", recovered)
+		}
+
+		if err := <-followerErr; err == nil || !strings.Contains(err.Error(), "boom") {
+			t.Errorf("expected the panic to be recorded as an error for the follower, not %v", err)
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+)
+
+// StreamArrayClient is an optional interface that can be implemented by a Client to stream responses that are JSON
+// arrays, rather than decoding the whole array at once. This is used by Binding.ExecuteEach.
+type StreamArrayClient interface {
+	Client
+	// RunEach should execute the given Request in the same way as Client.Run, but decode the response as a JSON array
+	// one element at a time. For each element, onElement should be called with a function that decodes the element
+	// into the given value. If onElement returns an error, then RunEach should stop and return that error.
+	RunEach(ctx context.Context, bindingName string, attrs map[string]any, req Request, onElement func(decode func(v any) error) error) error
+}
+
+// decodeJSONArray decodes the JSON array within the given io.Reader one element at a time using json.Decoder.Token and
+// json.Decoder.Decode, calling onElement for each element. This can be used to implement StreamArrayClient.RunEach.
+func decodeJSONArray(r io.Reader, onElement func(decode func(v any) error) error) (err error) {
+	decoder := json.NewDecoder(r)
+
+	var token json.Token
+	if token, err = decoder.Token(); err != nil {
+		return errors.Wrap(err, "could not read start of JSON array")
+	}
+
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("expected the start of a JSON array, not %v", token)
+	}
+
+	for elementNo := 0; decoder.More(); elementNo++ {
+		if err = onElement(decoder.Decode); err != nil {
+			return errors.Wrapf(err, "could not handle element no. %d of JSON array", elementNo)
+		}
+	}
+
+	if _, err = decoder.Token(); err != nil {
+		return errors.Wrap(err, "could not read end of JSON array")
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+// largeArrayClient is a StreamArrayClient that streams a JSON array of the given number of integers through an
+// io.Pipe, so that the whole array is never held in memory.
+type largeArrayClient struct {
+	*mockClient
+	items int
+}
+
+func (c largeArrayClient) RunEach(ctx context.Context, bindingName string, attrs map[string]any, req Request, onElement func(decode func(v any) error) error) error {
+	reader, writer := io.Pipe()
+	go func() {
+		_, _ = io.WriteString(writer, "[")
+		for i := 0; i < c.items; i++ {
+			if i > 0 {
+				_, _ = io.WriteString(writer, ",")
+			}
+			if _, err := fmt.Fprintf(writer, `{"id": %d}`, i); err != nil {
+				return
+			}
+		}
+		_, _ = io.WriteString(writer, "]")
+		_ = writer.Close()
+	}()
+	defer reader.Close()
+	return decodeJSONArray(reader, onElement)
+}
+
+func TestBindingProto_ExecuteEach(t *testing.T) {
+	type item struct {
+		ID int `json:"id"`
+	}
+
+	const items = 100000
+	binding := NewBindingChain[item, item](func(binding Binding[item, item], args ...any) Request {
+		req, _ := http.NewRequest(http.MethodGet, "https://example.com/items", nil)
+		return HTTPRequest{req}
+	})
+
+	client := largeArrayClient{mockClient: &mockClient{}, items: items}
+	expectedID := 0
+	if err := binding.ExecuteEach(client, func(i item) error {
+		if i.ID != expectedID {
+			return fmt.Errorf("expected item %d, not %d", expectedID, i.ID)
+		}
+		expectedID++
+		return nil
+	}); err != nil {
+		t.Fatalf("could not stream Binding: %v", err)
+	}
+
+	if expectedID != items {
+		t.Errorf("expected %d items to be streamed, not %d", items, expectedID)
+	}
+
+	stop := errors.New("stop")
+	streamed := 0
+	if err := binding.ExecuteEach(client, func(i item) error {
+		if streamed++; streamed == 10 {
+			return stop
+		}
+		return nil
+	}); errors.Cause(err) != stop {
+		t.Errorf("expected streaming to stop with %v, not %v", stop, err)
+	} else if streamed != 10 {
+		t.Errorf("expected 10 items to be streamed before stopping, not %d", streamed)
+	}
+
+	if err := binding.ExecuteEach(&mockClient{}, func(i item) error { return nil }); err == nil {
+		t.Errorf("expected an error when the Client is not a StreamArrayClient")
+	}
+}
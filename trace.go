@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+	"fmt"
+)
+
+// traceIDContextKey is the context.Context key for the trace ID set by WithTraceID.
+type traceIDContextKey struct{}
+
+// WithTraceID returns a copy of the given context.Context that carries the given trace ID. The trace ID is included in
+// the logs of an API (see WithLogger) and a RateLimitedClient when the context.Context is used to execute a Binding or
+// Paginator. The context.Context is also passed to Client.Run, where the Client can use TraceIDFromContext to
+// propagate the trace ID however it sees fit (e.g. as a request header).
+func WithTraceID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, traceIDContextKey{}, id)
+}
+
+// TraceIDFromContext returns the trace ID that was set using WithTraceID. The second return value is false if the
+// given context.Context does not carry a trace ID.
+func TraceIDFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	id, ok := ctx.Value(traceIDContextKey{}).(string)
+	return id, ok
+}
+
+// traceLogPrefix returns the prefix to add to log messages for the trace ID carried by the given context.Context. An
+// empty string is returned if there is no trace ID.
+func traceLogPrefix(ctx context.Context) string {
+	if id, ok := TraceIDFromContext(ctx); ok {
+		return fmt.Sprintf("[trace %s] ", id)
+	}
+	return ""
+}
+
+// BindingNameContextKey is the context.Context key for the name (a string) of the Binding that is being executed. It is
+// set on the context.Context that is passed to Client.Run, and can be read using BindingNameFromContext.
+type BindingNameContextKey struct{}
+
+// AttemptContextKey is the context.Context key for the attempt number (an int) of the execution of a Binding. It is set
+// on the context.Context that is passed to Client.Run, and can be read using AttemptFromContext.
+type AttemptContextKey struct{}
+
+// withExecution returns a copy of the given context.Context that carries the given Binding name and attempt number,
+// which is passed to Client.Run.
+func withExecution(ctx context.Context, bindingName string, attempt int) context.Context {
+	return context.WithValue(context.WithValue(ctx, BindingNameContextKey{}, bindingName), AttemptContextKey{}, attempt)
+}
+
+// BindingNameFromContext returns the name of the Binding that is being executed, from the context.Context that is
+// passed to Client.Run (or StreamArrayClient.RunEach). This allows a generic Client to log or trace each execution
+// without any extra parameters. The second return value is false if the given context.Context does not carry a Binding
+// name.
+func BindingNameFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	name, ok := ctx.Value(BindingNameContextKey{}).(string)
+	return name, ok
+}
+
+// AttemptFromContext returns the attempt number, starting at 1, of the execution of a Binding from the context.Context
+// that is passed to Client.Run. The attempt number is incremented each time the Binding is retried by its RetryPolicy.
+// The second return value is false if the given context.Context does not carry an attempt number.
+func AttemptFromContext(ctx context.Context) (int, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	attempt, ok := ctx.Value(AttemptContextKey{}).(int)
+	return attempt, ok
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithTraceID(t *testing.T) {
+	var traceIDs []string
+	client := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		if id, ok := TraceIDFromContext(ctx); ok {
+			traceIDs = append(traceIDs, id)
+		}
+		return true, nil
+	}}
+
+	logger := &recordingLogger{}
+	api := NewAPI(client, Schema{
+		"traced": WrapBinding(NewBindingChain(mockRequestMethod[bool, bool])),
+	}, WithLogger(logger))
+
+	if _, err := api.ExecuteCtx(WithTraceID(context.Background(), "abc123"), "traced"); err != nil {
+		t.Fatalf("could not execute Binding: %v", err)
+	}
+
+	// Executing without a trace ID should not add a trace ID to the context.Context or to the logs
+	if _, err := api.Execute("traced"); err != nil {
+		t.Fatalf("could not execute Binding: %v", err)
+	}
+
+	if len(traceIDs) != 1 || traceIDs[0] != "abc123" {
+		t.Errorf("expected the trace ID \"abc123\" to be readable once within Client.Run, got %v", traceIDs)
+	}
+
+	if len(*logger) != 2 {
+		t.Fatalf("expected 2 log messages, not %d: %v", len(*logger), *logger)
+	}
+
+	if !strings.HasPrefix((*logger)[0], "[trace abc123] ") {
+		t.Errorf("expected first log message to contain the trace ID, got %q", (*logger)[0])
+	}
+
+	if strings.Contains((*logger)[1], "[trace") {
+		t.Errorf("expected second log message to not contain a trace ID, got %q", (*logger)[1])
+	}
+}
+
+func TestBindingNameFromContext(t *testing.T) {
+	type execution struct {
+		name    string
+		attempt int
+	}
+
+	var executions []execution
+	client := &mockClient{}
+	client.run = func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		name, _ := BindingNameFromContext(ctx)
+		attempt, _ := AttemptFromContext(ctx)
+		executions = append(executions, execution{name, attempt})
+
+		// The exported keys can also be used to read the values directly
+		if ctx.Value(BindingNameContextKey{}) != name || ctx.Value(AttemptContextKey{}) != attempt {
+			t.Errorf("expected the context keys to carry %q and %d", name, attempt)
+		}
+		if client.requests == 1 {
+			return nil, retryAfterError{time.Millisecond}
+		}
+		return "ok", nil
+	}
+
+	binding := NewBindingChain(mockRequestMethod[string, string]).SetName("users").SetRetryPolicy(&RetryPolicy{
+		MaxAttempts: 2,
+		Backoff:     func(attempt int) time.Duration { return time.Millisecond },
+	})
+
+	if _, err := binding.Execute(client); err != nil {
+		t.Fatalf("could not execute Binding: %v", err)
+	}
+
+	if expected := []execution{{"users", 1}, {"users", 2}}; !reflect.DeepEqual(executions, expected) {
+		t.Errorf("expected the Client to read the executions %v from the context, not %v", expected, executions)
+	}
+
+	if _, ok := BindingNameFromContext(context.Background()); ok {
+		t.Errorf("expected no Binding name within a context that was not passed to Client.Run")
+	}
+}
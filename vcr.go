@@ -0,0 +1,219 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"os"
+	"sync"
+)
+
+// recordedRequest is the serialised form of a Request within a cassette. HTTPRequest(s) are recorded using their
+// method, URL, and body, and RecordableGraphQLRequest(s) are recorded using their query and variables.
+type recordedRequest struct {
+	Method string         `json:"method,omitempty"`
+	URL    string         `json:"url,omitempty"`
+	Body   string         `json:"body,omitempty"`
+	Query  string         `json:"query,omitempty"`
+	Vars   map[string]any `json:"vars,omitempty"`
+}
+
+// recordRequest serialises the given Request into a recordedRequest. The body of a HTTPRequest will be replaced so that
+// it can still be read by the Client that executes it.
+func recordRequest(req Request) (recorded recordedRequest, err error) {
+	switch req := req.(type) {
+	case HTTPRequest:
+		if req.Request == nil {
+			return recorded, fmt.Errorf("cannot record nil HTTPRequest")
+		}
+
+		recorded.Method = req.Method
+		recorded.URL = req.URL.String()
+		if req.Body != nil {
+			var body []byte
+			if body, err = io.ReadAll(req.Body); err != nil {
+				return recorded, errors.Wrapf(err, "could not read body of %s", describeRequest(req))
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+			recorded.Body = string(body)
+		}
+	case RecordableGraphQLRequest:
+		recorded.Query = req.Query()
+		recorded.Vars = req.Vars()
+	case GraphQLRequest:
+		return recorded, fmt.Errorf("cannot record GraphQLRequest as its query is unknown, create it using NewGraphQLRequest instead")
+	default:
+		return recorded, fmt.Errorf("cannot record Request of type %T", req)
+	}
+	return
+}
+
+// cassetteInteraction is a single request/response pair recorded within a cassette.
+type cassetteInteraction struct {
+	Binding  string          `json:"binding"`
+	Request  recordedRequest `json:"request"`
+	Response json.RawMessage `json:"response"`
+}
+
+// key returns the key used to match a Request executed by a Binding against the cassetteInteraction.
+func (ci cassetteInteraction) key() (string, error) {
+	request, err := json.Marshal(ci.Request)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not marshal request for Binding %q", ci.Binding)
+	}
+	return ci.Binding + " " + string(request), nil
+}
+
+// cassette is a set of cassetteInteraction(s) that is stored in a JSON file. The zero value is an empty cassette.
+type cassette struct {
+	interactions []cassetteInteraction
+	index        map[string]int
+}
+
+// loadCassette reads the cassette from the file at the given path. If the file does not exist and mustExist is false,
+// then an empty cassette is returned.
+func loadCassette(path string, mustExist bool) (c cassette, err error) {
+	c.index = make(map[string]int)
+
+	var data []byte
+	if data, err = os.ReadFile(path); err != nil {
+		if os.IsNotExist(err) && !mustExist {
+			err = nil
+		}
+		return
+	}
+
+	if err = json.Unmarshal(data, &c.interactions); err != nil {
+		err = errors.Wrapf(err, "could not unmarshal cassette %q", path)
+		return
+	}
+
+	for i, interaction := range c.interactions {
+		var key string
+		if key, err = interaction.key(); err != nil {
+			return
+		}
+
+		// The first recorded interaction for a request takes precedence
+		if _, ok := c.index[key]; !ok {
+			c.index[key] = i
+		}
+	}
+	return
+}
+
+// find returns the recorded response for the Request executed by the Binding of the given name.
+func (c *cassette) find(bindingName string, req Request) (interaction cassetteInteraction, key string, ok bool, err error) {
+	interaction.Binding = bindingName
+	if interaction.Request, err = recordRequest(req); err != nil {
+		return
+	}
+
+	if key, err = interaction.key(); err != nil {
+		return
+	}
+
+	var i int
+	if i, ok = c.index[key]; ok {
+		interaction = c.interactions[i]
+	}
+	return
+}
+
+// save writes the cassette to the file at the given path.
+func (c *cassette) save(path string) error {
+	data, err := json.MarshalIndent(c.interactions, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "could not marshal cassette")
+	}
+	return errors.Wrapf(os.WriteFile(path, data, 0644), "could not write cassette %q", path)
+}
+
+// RecordingClient is a Client that records the Request(s) executed by each Binding, along with their decoded responses,
+// to a cassette file (in the same vein as Ruby's VCR). Request(s) that have already been recorded within the cassette
+// are replayed from the cassette without calling the inner Client, so only the first run of a test will hit the
+// network. Only HTTPRequest(s) and RecordableGraphQLRequest(s) can be recorded.
+type RecordingClient struct {
+	inner    Client
+	path     string
+	mutex    sync.Mutex
+	cassette cassette
+}
+
+// NewRecordingClient creates a new RecordingClient that records Request(s) executed by the given inner Client to the
+// cassette file at the given path. If the cassette file already exists, then its recorded interactions will be loaded
+// so that they can be replayed.
+func NewRecordingClient(inner Client, cassette string) (client *RecordingClient, err error) {
+	client = &RecordingClient{inner: inner, path: cassette}
+	if client.cassette, err = loadCassette(cassette, false); err != nil {
+		client = nil
+	}
+	return
+}
+
+func (c *RecordingClient) Run(ctx context.Context, bindingName string, attrs map[string]any, req Request, res any) (err error) {
+	c.mutex.Lock()
+	interaction, key, ok, err := c.cassette.find(bindingName, req)
+	c.mutex.Unlock()
+	if err != nil {
+		return errors.Wrapf(err, "could not look up Request for Binding %q in cassette %q", bindingName, c.path)
+	}
+
+	if ok {
+		return errors.Wrapf(
+			json.Unmarshal(interaction.Response, res),
+			"could not unmarshal recorded response for Binding %q into %T", bindingName, res,
+		)
+	}
+
+	if err = c.inner.Run(ctx, bindingName, attrs, req, res); err != nil {
+		return
+	}
+
+	if interaction.Response, err = json.Marshal(res); err != nil {
+		return errors.Wrapf(err, "could not marshal response for Binding %q to record it", bindingName)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok = c.cassette.index[key]; !ok {
+		c.cassette.index[key] = len(c.cassette.interactions)
+		c.cassette.interactions = append(c.cassette.interactions, interaction)
+	}
+	return c.cassette.save(c.path)
+}
+
+// ReplayClient is a Client that serves responses from a cassette file that was recorded by a RecordingClient. It never
+// hits the network, and returns an error for any Request that was not recorded within the cassette.
+type ReplayClient struct {
+	path     string
+	cassette cassette
+}
+
+// NewReplayClient creates a new ReplayClient that replays the interactions recorded within the cassette file at the
+// given path. An error is returned if the cassette file does not exist.
+func NewReplayClient(cassette string) (client *ReplayClient, err error) {
+	client = &ReplayClient{path: cassette}
+	if client.cassette, err = loadCassette(cassette, true); err != nil {
+		client = nil
+		err = errors.Wrapf(err, "could not load cassette %q", cassette)
+	}
+	return
+}
+
+func (c *ReplayClient) Run(ctx context.Context, bindingName string, attrs map[string]any, req Request, res any) error {
+	interaction, _, ok, err := c.cassette.find(bindingName, req)
+	switch {
+	case err != nil:
+		return errors.Wrapf(err, "could not look up Request for Binding %q in cassette %q", bindingName, c.path)
+	case !ok:
+		return fmt.Errorf("no response was recorded for Binding %q (%s) in cassette %q", bindingName, describeRequest(req), c.path)
+	}
+	return errors.Wrapf(
+		json.Unmarshal(interaction.Response, res),
+		"could not unmarshal recorded response for Binding %q into %T", bindingName, res,
+	)
+}
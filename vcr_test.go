@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"github.com/machinebox/graphql"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRecordingClient(t *testing.T) {
+	var hits atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		_, _ = w.Write([]byte(`{"id": "` + r.URL.Query().Get("id") + `"}`))
+	}))
+	defer server.Close()
+
+	binding := NewRESTBinding[map[string]any, map[string]any](http.MethodGet, server.URL, func(binding Binding[map[string]any, map[string]any]) []BindingParam {
+		return Params("id", "", true)
+	}, false).SetName("user")
+
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+	execute := func(client Client) []map[string]any {
+		responses := make([]map[string]any, 0)
+		for _, id := range []string{"1", "2", "1"} {
+			response, err := binding.Execute(client, id)
+			if err != nil {
+				t.Fatalf("could not execute Binding for id %q: %v", id, err)
+			}
+			responses = append(responses, response)
+		}
+		return responses
+	}
+	expected := []map[string]any{{"id": "1"}, {"id": "2"}, {"id": "1"}}
+
+	// First run: the requests are recorded
+	recorder, err := NewRecordingClient(NewHTTPClient(), cassette)
+	if err != nil {
+		t.Fatalf("could not create RecordingClient: %v", err)
+	}
+
+	if actual := execute(recorder); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected recorded responses %v, not %v", expected, actual)
+	}
+
+	if hits.Load() != 2 {
+		t.Errorf("expected 2 hits to the server when recording, not %d", hits.Load())
+	}
+
+	// Second run: the requests are replayed from the cassette
+	if recorder, err = NewRecordingClient(NewHTTPClient(), cassette); err != nil {
+		t.Fatalf("could not create RecordingClient from existing cassette: %v", err)
+	}
+
+	if actual := execute(recorder); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected replayed responses %v, not %v", expected, actual)
+	}
+
+	if hits.Load() != 2 {
+		t.Errorf("expected no more hits to the server when replaying, got %d", hits.Load())
+	}
+
+	// The ReplayClient should never hit the network
+	server.Close()
+	replayer, err := NewReplayClient(cassette)
+	if err != nil {
+		t.Fatalf("could not create ReplayClient: %v", err)
+	}
+
+	if actual := execute(replayer); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected replayed responses %v, not %v", expected, actual)
+	}
+
+	if _, err = binding.Execute(replayer, "3"); err == nil || !strings.Contains(err.Error(), "no response was recorded") {
+		t.Errorf("expected an error for a request that was not recorded, got %v", err)
+	}
+
+	if _, err = NewReplayClient(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Errorf("expected an error for a missing cassette")
+	}
+}
+
+func TestRecordingClient_GraphQL(t *testing.T) {
+	var runs int
+	inner := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		runs++
+		return map[string]any{"name": req.(RecordableGraphQLRequest).Vars()["name"]}, nil
+	}}
+
+	binding := NewBindingChain(func(binding Binding[map[string]any, map[string]any], args ...any) Request {
+		return NewGraphQLRequest("query Greet($name: String!) { greet(name: $name) }", map[string]any{"name": args[0]})
+	}).SetParamsMethod(func(binding Binding[map[string]any, map[string]any]) []BindingParam {
+		return Params("name", "", true)
+	}).SetName("greet")
+
+	recorder, err := NewRecordingClient(inner, filepath.Join(t.TempDir(), "cassette.json"))
+	if err != nil {
+		t.Fatalf("could not create RecordingClient: %v", err)
+	}
+
+	for _, name := range []string{"andy", "andy", "bob"} {
+		if response, err := binding.Execute(recorder, name); err != nil {
+			t.Errorf("could not execute Binding for %q: %v", name, err)
+		} else if response["name"] != name {
+			t.Errorf("expected name %q, not %v", name, response["name"])
+		}
+	}
+
+	if runs != 2 {
+		t.Errorf("expected the inner Client to run 2 times, not %d", runs)
+	}
+}
+
+func TestRecordingClient_GraphQLNotRecordable(t *testing.T) {
+	inner := &mockClient{run: func(ctx context.Context, bindingName string, attrs map[string]any, req Request) (any, error) {
+		return map[string]any{}, nil
+	}}
+
+	// The query and variables of a GraphQLRequest cannot be read, so it cannot be recorded
+	binding := NewBindingChain(func(binding Binding[map[string]any, map[string]any], args ...any) Request {
+		return GraphQLRequest{graphql.NewRequest("query Greet { greet }")}
+	}).SetName("greet")
+
+	recorder, err := NewRecordingClient(inner, filepath.Join(t.TempDir(), "cassette.json"))
+	if err != nil {
+		t.Fatalf("could not create RecordingClient: %v", err)
+	}
+
+	if _, err = binding.Execute(recorder); err == nil || !strings.Contains(err.Error(), "NewGraphQLRequest") {
+		t.Errorf("expected an error for a GraphQLRequest that is not a RecordableGraphQLRequest, got %v", err)
+	}
+}